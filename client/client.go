@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -22,6 +23,8 @@ type Client struct {
 	apiUrl     string
 	userAgent  string
 	HttpClient HttpClient
+
+	retryPolicy *RetryPolicy
 }
 
 var (
@@ -93,6 +96,7 @@ func applyDefaults(c *Client) {
 		WithDefaultClient(),
 		WithDefaultApiUrl(),
 		WithDefaultUserAgent(),
+		WithDefaultRetryPolicy(),
 	}
 	for _, opt := range defaultOptions {
 		opt(c)
@@ -168,20 +172,32 @@ func WithDefaultUserAgent() Option {
 	}
 }
 
-func (c *Client) do(method string, path string, body interface{}, result interface{}) error {
+// requestOption customizes a single API request.
+type requestOption func(*http.Request)
+
+func withHeader(key, value string) requestOption {
+	return func(req *http.Request) {
+		req.Header.Set(key, value)
+	}
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, result interface{}, opts ...requestOption) error {
 
 	u, err := c.getURL(path)
 	if err != nil {
 		return err
 	}
-	req, err := c.newRequest(method, u, body)
+	req, err := c.newRequest(ctx, method, u, body)
 	if err != nil {
 		return err
 	}
+	for _, opt := range opts {
+		opt(req)
+	}
 	return c.doRequest(req, result)
 }
 
-func (c *Client) newRequest(method string, u *url.URL, body interface{}) (*http.Request, error) {
+func (c *Client) newRequest(ctx context.Context, method string, u *url.URL, body interface{}) (*http.Request, error) {
 	var buf io.ReadWriter
 	if body != nil {
 		buf = new(bytes.Buffer)
@@ -190,7 +206,7 @@ func (c *Client) newRequest(method string, u *url.URL, body interface{}) (*http.
 			return nil, err
 		}
 	}
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -205,39 +221,41 @@ func (c *Client) newRequest(method string, u *url.URL, body interface{}) (*http.
 }
 
 func (c *Client) doRequest(req *http.Request, v any) error {
-	res, err := c.HttpClient.Do(req)
+	res, err := c.sendWithRetry(req)
 	if err != nil {
 		return err
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= http.StatusBadRequest {
-		return parseError(res.Body)
+		return parseError(res)
 	}
 
-	return decodeResponse(res.Body, v)
+	return decodeResponse(res, v)
 }
 
-func parseError(body io.Reader) error {
+func parseError(res *http.Response) error {
 
-	b, err := io.ReadAll(body)
+	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return err
 	}
-	var e Error
-	err = json.Unmarshal(b, &e)
-	if err != nil {
-		return err
+
+	e := new(Error)
+	if err := json.Unmarshal(b, e); err != nil || (e.HTTPStatusCode == 0 && e.Message == "") {
+		e = newRawError(res.StatusCode, b)
 	}
+	e.StatusCode = res.StatusCode
+	e.RequestID = res.Header.Get("X-Request-Id")
 
 	return e
 }
 
-func decodeResponse(body io.Reader, v any) error {
+func decodeResponse(res *http.Response, v any) error {
 	if v == nil {
 		return nil
 	}
-	b, err := io.ReadAll(body)
+	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return err
 	}
@@ -245,6 +263,8 @@ func decodeResponse(body io.Reader, v any) error {
 	var apierr Error
 	err = json.Unmarshal(b, &apierr)
 	if err == nil && apierr.HTTPStatusCode != 0 {
+		apierr.StatusCode = res.StatusCode
+		apierr.RequestID = res.Header.Get("X-Request-Id")
 		return &apierr
 	}
 	err = json.Unmarshal(b, v)
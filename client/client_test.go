@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.GetCluster(ctx, "user", "cluster")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetCluster() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClient_FindClusterByName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[
+			{"spec":{"id":"1","name":"old"},"status":{"status":"Deleted"}},
+			{"spec":{"id":"2","name":"old"},"status":{"status":"Ready"}},
+			{"spec":{"id":"3","name":"twin"},"status":{"status":"Ready"}},
+			{"spec":{"id":"4","name":"twin"},"status":{"status":"Ready"}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	cluster, err := c.FindClusterByName(context.Background(), "user", "old")
+	if err != nil {
+		t.Fatalf("FindClusterByName() error = %v", err)
+	}
+	if cluster.Spec.ID != "2" {
+		t.Errorf("FindClusterByName() id = %q, want %q", cluster.Spec.ID, "2")
+	}
+
+	if _, err := c.FindClusterByName(context.Background(), "user", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FindClusterByName() error = %v, want %v", err, ErrNotFound)
+	}
+
+	if _, err := c.FindClusterByName(context.Background(), "user", "twin"); err == nil {
+		t.Errorf("FindClusterByName() error = nil, want an error for duplicate names")
+	}
+}
+
+func TestClient_ClusterIterator(t *testing.T) {
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"items":[
+				{"spec":{"id":"1","name":"a","plan":"Starter"}},
+				{"spec":{"id":"2","name":"b","plan":"Enterprise"}}
+			],"next_cursor":"page2"}`))
+		case "page2":
+			_, _ = w.Write([]byte(`{"items":[
+				{"spec":{"id":"3","name":"c","plan":"Starter"}}
+			]}`))
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	clusters, err := c.ListAllClusters(context.Background(), "user", ListClustersOptions{Plan: CNPGClusterPlanStarter, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListAllClusters() error = %v", err)
+	}
+
+	var ids []string
+	for _, cluster := range clusters {
+		ids = append(ids, cluster.Spec.ID)
+	}
+	if strings.Join(ids, ",") != "1,3" {
+		t.Errorf("ListAllClusters() ids = %v, want [1 3]", ids)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("ListAllClusters() requests = %d, want 2", len(queries))
+	}
+	if got := queries[0].Get("plan"); got != "Starter" {
+		t.Errorf("ListAllClusters() plan filter = %q, want %q", got, "Starter")
+	}
+	if got := queries[0].Get("page_size"); got != "2" {
+		t.Errorf("ListAllClusters() page_size = %q, want %q", got, "2")
+	}
+}
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -37,6 +41,7 @@ var (
 	ServerResourceAWSM7ILarge  ServerResource = "aws-m7i-large-2c-8g"
 	ServerResourceAWSR7ILarge  ServerResource = "aws-r7i-large-2c-16g"
 	ServerResourceAWSR7IXLarge ServerResource = "aws-r7i-xlarge-4c-32g"
+	ServerResourceAWSI4IXLarge ServerResource = "aws-i4i-xlarge-4c-32g"
 )
 
 type CNPGClusterType string
@@ -65,6 +70,14 @@ type CNPGClusterPlanInfo struct {
 	Plan        CNPGClusterPlan `json:"plan"`
 	Description string          `json:"description"`
 	Type        CNPGClusterType `json:"type"`
+	// ServerResources are the server resources clusters of the plan can
+	// run on.
+	ServerResources []ServerResource `json:"server_resources,omitempty"`
+	// MinDiskSize and MaxDiskSize bound the PGData disk size in GiB.
+	MinDiskSize int `json:"min_disk_size,omitempty"`
+	MaxDiskSize int `json:"max_disk_size,omitempty"`
+	// Features are the capabilities of the clusters of the plan.
+	Features CNPGClusterPlanFeatures `json:"features"`
 }
 
 type CNPGCluster struct {
@@ -106,6 +119,62 @@ type CNPGClusterSpec struct {
 type CNPGClusterList struct {
 	// Items is the list of the clusters.
 	Items []CNPGCluster `json:"items"`
+	// NextCursor is the cursor of the next page, empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListClustersOptions filters and pages the clusters returned by ListClusters.
+// Empty fields do not filter.
+type ListClustersOptions struct {
+	Status     ClusterStatus
+	Region     string
+	Plan       CNPGClusterPlan
+	NamePrefix string
+	// PageSize is the maximum number of clusters per page. Zero lets the
+	// API pick its default.
+	PageSize int
+	// Cursor is the NextCursor of the previous page, empty for the first one.
+	Cursor string
+}
+
+func (opts ListClustersOptions) query() url.Values {
+	q := url.Values{}
+	if opts.Status != "" {
+		q.Set("status", string(opts.Status))
+	}
+	if opts.Region != "" {
+		q.Set("region", opts.Region)
+	}
+	if opts.Plan != "" {
+		q.Set("plan", string(opts.Plan))
+	}
+	if opts.NamePrefix != "" {
+		q.Set("name_prefix", opts.NamePrefix)
+	}
+	if opts.PageSize > 0 {
+		q.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+	if opts.Cursor != "" {
+		q.Set("cursor", opts.Cursor)
+	}
+	return q
+}
+
+// matches reports whether cluster passes the filters. The filters are also
+// applied client side in case the API ignores some of them.
+func (opts ListClustersOptions) matches(cluster CNPGCluster) bool {
+	switch {
+	case opts.Status != "" && cluster.Status.Status != opts.Status:
+		return false
+	case opts.Region != "" && cluster.Spec.ClusterProvider.Region != opts.Region:
+		return false
+	case opts.Plan != "" && cluster.Spec.Plan != opts.Plan:
+		return false
+	case opts.NamePrefix != "" && !strings.HasPrefix(cluster.Spec.Name, opts.NamePrefix):
+		return false
+	default:
+		return true
+	}
 }
 
 type PostgreSQLConfig struct {
@@ -137,28 +206,182 @@ type CNPGClusterUpgradeRequest struct {
 	PGDataDiskSize string `json:"pg_data_disk_size"`
 }
 
-func (c *Client) CreateCluster(params CNPGClusterSpec, userID string) (*CNPGCluster, error) {
+// CreateCluster creates a cluster. The request carries a new idempotency key
+// so that retries of the same call never create a second cluster.
+func (c *Client) CreateCluster(ctx context.Context, params CNPGClusterSpec, userID string) (*CNPGCluster, error) {
 	if params.PostgreSQLConfig.Instances == 0 {
 		params.PostgreSQLConfig.Instances = 1
 	}
 
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var clusterResponse CNPGCluster
+	err = c.do(ctx, "POST", fmt.Sprintf("users/%s/cnpgs", userID), params, &clusterResponse, withHeader(idempotencyKeyHeader, key))
+	return &clusterResponse, err
+}
+
+// ListClusters returns one page of the clusters of the account. Use
+// NextCursor to fetch the following page, or ClusterIterator to walk all of
+// them.
+func (c *Client) ListClusters(ctx context.Context, userID string, opts ListClustersOptions) (*CNPGClusterList, error) {
+	path := fmt.Sprintf("users/%s/cnpgs", userID)
+	if q := opts.query(); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var list CNPGClusterList
+	err := c.do(ctx, "GET", path, nil, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	items := list.Items[:0]
+	for _, cluster := range list.Items {
+		if opts.matches(cluster) {
+			items = append(items, cluster)
+		}
+	}
+	list.Items = items
+	return &list, nil
+}
+
+// ListAllClusters returns the clusters of the account across all pages.
+func (c *Client) ListAllClusters(ctx context.Context, userID string, opts ListClustersOptions) ([]CNPGCluster, error) {
+	var clusters []CNPGCluster
+	it := c.NewClusterIterator(userID, opts)
+	for it.Next(ctx) {
+		clusters = append(clusters, it.Cluster())
+	}
+	return clusters, it.Err()
+}
+
+// FindClusterByName returns the cluster named name in the account. Deleted
+// clusters are ignored. It fails with ErrNotFound when there is no such
+// cluster.
+func (c *Client) FindClusterByName(ctx context.Context, userID string, name string) (*CNPGCluster, error) {
+	clusters, err := c.ListAllClusters(ctx, userID, ListClustersOptions{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var found []CNPGCluster
+	for _, cluster := range clusters {
+		if cluster.Spec.Name == name && cluster.Status.Status != CNPGClusterStatusDeleted {
+			found = append(found, cluster)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("cluster %q: %w", name, ErrNotFound)
+	case 1:
+		return &found[0], nil
+	default:
+		ids := make([]string, 0, len(found))
+		for _, cluster := range found {
+			ids = append(ids, cluster.Spec.ID)
+		}
+		return nil, fmt.Errorf("found %d clusters named %q (%s), use the cluster id instead", len(found), name, strings.Join(ids, ", "))
+	}
+}
+
+// ClusterIterator walks the clusters of an account page by page.
+//
+//	it := c.NewClusterIterator(userID, client.ListClustersOptions{})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Cluster().Spec.Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ClusterIterator struct {
+	client  *Client
+	userID  string
+	opts    ListClustersOptions
+	page    []CNPGCluster
+	current CNPGCluster
+	err     error
+	done    bool
+}
+
+func (c *Client) NewClusterIterator(userID string, opts ListClustersOptions) *ClusterIterator {
+	return &ClusterIterator{
+		client: c,
+		userID: userID,
+		opts:   opts,
+	}
+}
+
+// Next advances to the next cluster, fetching the next page when needed. It
+// returns false when there are no more clusters or an error occurred.
+func (it *ClusterIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		list, err := it.client.ListClusters(ctx, it.userID, it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = list.Items
+		switch {
+		case list.NextCursor == "":
+			it.done = true
+		case list.NextCursor == it.opts.Cursor:
+			it.err = fmt.Errorf("list clusters: cursor %q did not advance", list.NextCursor)
+		default:
+			it.opts.Cursor = list.NextCursor
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Cluster returns the cluster Next advanced to.
+func (it *ClusterIterator) Cluster() CNPGCluster {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *ClusterIterator) Err() error {
+	return it.err
+}
+
+func (c *Client) GetCluster(ctx context.Context, userID string, clusterID string) (*CNPGCluster, error) {
+	var clusterResponse CNPGCluster
+	err := c.do(ctx, "GET", fmt.Sprintf("users/%s/cnpgs/%s", userID, clusterID), nil, &clusterResponse)
+	return &clusterResponse, err
+}
+
+func (c *Client) UpgradeCluster(ctx context.Context, userID string, clusterID string, params CNPGClusterUpgradeRequest) (*CNPGCluster, error) {
 	var clusterResponse CNPGCluster
-	err := c.do("POST", fmt.Sprintf("users/%s/cnpgs", userID), params, &clusterResponse)
+	err := c.do(ctx, "PUT", fmt.Sprintf("users/%s/cnpgs/%s/upgrade", userID, clusterID), params, &clusterResponse)
 	return &clusterResponse, err
 }
 
-func (c *Client) GetCluster(userID string, clusterID string) (*CNPGCluster, error) {
+// SuspendCluster stops the instances of a Ready cluster, keeping its data. The
+// cluster moves to the Suspended status.
+func (c *Client) SuspendCluster(ctx context.Context, userID, clusterID string) (*CNPGCluster, error) {
 	var clusterResponse CNPGCluster
-	err := c.do("GET", fmt.Sprintf("users/%s/cnpgs/%s", userID, clusterID), nil, &clusterResponse)
+	err := c.do(ctx, "PUT", fmt.Sprintf("users/%s/cnpgs/%s/suspend", userID, clusterID), nil, &clusterResponse)
 	return &clusterResponse, err
 }
 
-func (c *Client) UpgradeCluster(userID string, clusterID string, params CNPGClusterUpgradeRequest) (*CNPGCluster, error) {
+// ResumeCluster starts the instances of a Suspended cluster again. The cluster
+// moves to the Resuming status, then to Ready.
+func (c *Client) ResumeCluster(ctx context.Context, userID, clusterID string) (*CNPGCluster, error) {
 	var clusterResponse CNPGCluster
-	err := c.do("PUT", fmt.Sprintf("users/%s/cnpgs/%s/upgrade", userID, clusterID), params, &clusterResponse)
+	err := c.do(ctx, "PUT", fmt.Sprintf("users/%s/cnpgs/%s/resume", userID, clusterID), nil, &clusterResponse)
 	return &clusterResponse, err
 }
 
-func (c *Client) DeleteCluster(userID, clusterID string) error {
-	return c.do("DELETE", fmt.Sprintf("users/%s/cnpgs/%s", userID, clusterID), nil, nil)
+func (c *Client) DeleteCluster(ctx context.Context, userID, clusterID string) error {
+	return c.do(ctx, "DELETE", fmt.Sprintf("users/%s/cnpgs/%s", userID, clusterID), nil, nil)
 }
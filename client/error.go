@@ -1,10 +1,37 @@
 package client
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
+var (
+	// ErrUnauthorized is returned when the API key is missing, invalid or expired.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrPermissionDenied is returned when the API key is not allowed to access the resource.
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrNotFound is returned when the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when the request conflicts with the current state of the resource.
+	ErrConflict = errors.New("conflict")
+	// ErrQuotaExceeded is returned when the account has reached one of its limits.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+)
+
+// apiCodeErrors maps the API error codes that do not follow the HTTP status
+// of their category to a sentinel error.
+var apiCodeErrors = map[int]error{
+	40005: ErrPermissionDenied,
+}
+
+// maxErrorBodySize bounds how much of a non-JSON error body is kept.
+const maxErrorBodySize = 512
+
 type Error struct {
+	// HTTPStatusCode is the API error code, e.g. 40005. Despite its name it is
+	// the HTTP status followed by a two digit reason.
 	HTTPStatusCode int `json:"http_status_code,omitempty"`
 
 	// Human-readable message.
@@ -13,11 +40,26 @@ type Error struct {
 
 	// Logical operation and nested error.
 	Op  string `json:"op,omitempty"`
-	Err error  `json:"error,omitempty"`
+	Err error  `json:"-"`
+
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// RequestID identifies the request in the API logs.
+	RequestID string `json:"-"`
+	// Body is the raw response body when it could not be decoded.
+	Body string `json:"-"`
 }
 
 func (err Error) Error() string {
-	return fmt.Sprintf("code:%d,Message:%s", err.HTTPStatusCode, err.Message)
+	code := err.HTTPStatusCode
+	if code == 0 {
+		code = err.StatusCode
+	}
+	msg := fmt.Sprintf("code:%d,Message:%s", code, err.Message)
+	if err.RequestID != "" {
+		msg += fmt.Sprintf(",RequestID:%s", err.RequestID)
+	}
+	return msg
 }
 
 func (err Error) Is(target error) bool {
@@ -27,3 +69,66 @@ func (err Error) Is(target error) bool {
 	}
 	return t.HTTPStatusCode == err.HTTPStatusCode
 }
+
+// Unwrap exposes the sentinel error matching the API code, so callers can
+// use errors.Is(err, ErrNotFound), and the nested error if any.
+func (err Error) Unwrap() []error {
+	var errs []error
+	if sentinel := err.sentinel(); sentinel != nil {
+		errs = append(errs, sentinel)
+	}
+	if err.Err != nil {
+		errs = append(errs, err.Err)
+	}
+	return errs
+}
+
+func (err Error) sentinel() error {
+	if sentinel, ok := apiCodeErrors[err.HTTPStatusCode]; ok {
+		return sentinel
+	}
+	if strings.Contains(strings.ToLower(err.Message), "quota") {
+		return ErrQuotaExceeded
+	}
+
+	status := err.StatusCode
+	if err.HTTPStatusCode >= 100*100 {
+		status = err.HTTPStatusCode / 100
+	} else if err.HTTPStatusCode != 0 {
+		status = err.HTTPStatusCode
+	}
+
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusPaymentRequired:
+		return ErrQuotaExceeded
+	default:
+		return nil
+	}
+}
+
+// newRawError builds an Error from a response body that is not a JSON API
+// error, such as an HTML page served by a proxy or an empty body.
+func newRawError(statusCode int, body []byte) *Error {
+	raw := strings.TrimSpace(string(body))
+	if len(raw) > maxErrorBodySize {
+		raw = raw[:maxErrorBodySize] + "..."
+	}
+
+	message := http.StatusText(statusCode)
+	if raw != "" && !strings.HasPrefix(raw, "<") {
+		message = raw
+	}
+	return &Error{
+		StatusCode: statusCode,
+		Message:    message,
+		Body:       raw,
+	}
+}
@@ -2,6 +2,9 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -29,3 +32,70 @@ func TestError_UnmarshalJSON(t *testing.T) {
 		t.Errorf("Error.UnmarshalJSON() = %v, want %v", e.Message, wantMessage)
 	}
 }
+
+func TestError_Sentinels(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "api code", err: &Error{HTTPStatusCode: 40005}, want: ErrPermissionDenied},
+		{name: "api code category", err: &Error{HTTPStatusCode: 40401}, want: ErrNotFound},
+		{name: "http status", err: &Error{StatusCode: http.StatusUnauthorized}, want: ErrUnauthorized},
+		{name: "conflict", err: &Error{HTTPStatusCode: 40900}, want: ErrConflict},
+		{name: "quota message", err: &Error{HTTPStatusCode: 40000, Message: "cluster quota exceeded"}, want: ErrQuotaExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.want)
+			}
+		})
+	}
+
+	if errors.Is(&Error{StatusCode: http.StatusInternalServerError}, ErrNotFound) {
+		t.Errorf("errors.Is(500, ErrNotFound) = true, want false")
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		want        error
+	}{
+		{name: "json", status: http.StatusBadRequest, body: string(errJson), wantMessage: "your api key does not have permission to access this resource", want: ErrPermissionDenied},
+		{name: "html", status: http.StatusNotFound, body: "<html><body>Not Found</body></html>", wantMessage: "Not Found", want: ErrNotFound},
+		{name: "empty", status: http.StatusUnauthorized, body: "", wantMessage: "Unauthorized", want: ErrUnauthorized},
+		{name: "plain text", status: http.StatusConflict, body: "cluster already exists\n", wantMessage: "cluster already exists", want: ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{"X-Request-Id": []string{"req-1"}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			err := parseError(res)
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("parseError() = %v, want *Error", err)
+			}
+			if apiErr.Message != tt.wantMessage {
+				t.Errorf("parseError() message = %q, want %q", apiErr.Message, tt.wantMessage)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("parseError() status = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.RequestID != "req-1" {
+				t.Errorf("parseError() request id = %q, want %q", apiErr.RequestID, "req-1")
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.want)
+			}
+		})
+	}
+}
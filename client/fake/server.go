@@ -0,0 +1,677 @@
+// Package fake implements an in-process PGVecto.rs Cloud API server for
+// hermetic tests. Clusters are kept in memory and move through the same
+// statuses as on the real service, and faults can be injected per operation.
+//
+//	srv := fake.NewServer()
+//	defer srv.Close()
+//
+//	c, err := client.NewClient(client.WithApiKey("test"), client.OverrideApiUrl(srv.URL))
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Op identifies an API operation for fault injection and call counting.
+type Op string
+
+const (
+	OpCreate  Op = "create"
+	OpList    Op = "list"
+	OpGet     Op = "get"
+	OpUpgrade Op = "upgrade"
+	OpDelete  Op = "delete"
+	OpSuspend Op = "suspend"
+	OpResume  Op = "resume"
+
+	OpListImages          Op = "list-images"
+	OpListServerResources Op = "list-server-resources"
+	OpListRegions         Op = "list-regions"
+	OpListPlans           Op = "list-plans"
+)
+
+// DefaultImages is the image catalog served unless replaced with SetImages.
+var DefaultImages = []client.Image{
+	{Tag: "14-v0.4.0-extensions-exts", Image: "modelzai/pgvecto-rs:14-v0.4.0-extensions-exts", PGMajorVersion: 14, Extension: client.ImageExtensionPGVectoRS, ExtensionVersion: "v0.4.0"},
+	{Tag: "15-v0.4.0-extensions-exts", Image: "modelzai/pgvecto-rs:15-v0.4.0-extensions-exts", PGMajorVersion: 15, Extension: client.ImageExtensionPGVectoRS, ExtensionVersion: "v0.4.0"},
+	{Tag: "16-v0.3.0-extensions-exts", Image: "modelzai/pgvecto-rs:16-v0.3.0-extensions-exts", PGMajorVersion: 16, Extension: client.ImageExtensionPGVectoRS, ExtensionVersion: "v0.3.0", Deprecated: true},
+	{Tag: "16-v0.4.0-extensions-exts", Image: "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts", PGMajorVersion: 16, Extension: client.ImageExtensionPGVectoRS, ExtensionVersion: "v0.4.0"},
+	{Tag: "16-v0.2.0", Image: "modelzai/vchord-cnpg:16-v0.2.0", PGMajorVersion: 16, Extension: client.ImageExtensionVectorChord, ExtensionVersion: "v0.2.0"},
+	{Tag: "17-v0.1.0", Image: "modelzai/vchord-cnpg:17-v0.1.0", PGMajorVersion: 17, Extension: client.ImageExtensionVectorChord, ExtensionVersion: "v0.1.0", Deprecated: true},
+	{Tag: "17-v0.2.0", Image: "modelzai/vchord-cnpg:17-v0.2.0", PGMajorVersion: 17, Extension: client.ImageExtensionVectorChord, ExtensionVersion: "v0.2.0"},
+}
+
+// DefaultServerResources is the instance type catalog served unless replaced
+// with SetServerResources.
+var DefaultServerResources = []client.ServerResourceInfo{
+	{Name: client.ServerResourceAWST3XLarge, ClusterProvider: client.AWSCloudProvider, VCPU: 4, MemoryGiB: 16, PricePerHour: 0.1664, Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanStarter}, Regions: []string{"us-east-1", "eu-west-1"}},
+	{Name: client.ServerResourceAWSM7ILarge, ClusterProvider: client.AWSCloudProvider, VCPU: 2, MemoryGiB: 8, PricePerHour: 0.1008, Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanEnterprise}, Regions: []string{"us-east-1", "eu-west-1"}},
+	{Name: client.ServerResourceAWSR7ILarge, ClusterProvider: client.AWSCloudProvider, VCPU: 2, MemoryGiB: 16, PricePerHour: 0.1323, Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanEnterprise}, Regions: []string{"us-east-1", "eu-west-1"}},
+	{Name: client.ServerResourceAWSR7IXLarge, ClusterProvider: client.AWSCloudProvider, VCPU: 4, MemoryGiB: 32, PricePerHour: 0.2646, Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanEnterprise}, Regions: []string{"us-east-1", "eu-west-1"}},
+	{Name: client.ServerResourceAWSI4IXLarge, ClusterProvider: client.AWSCloudProvider, VCPU: 4, MemoryGiB: 32, PricePerHour: 0.343, Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanEnterprise}, Regions: []string{"us-east-1"}},
+}
+
+// DefaultRegions is the region catalog served unless replaced with
+// SetRegions.
+var DefaultRegions = []client.RegionInfo{
+	{Name: "us-east-1", ClusterProvider: client.AWSCloudProvider, Description: "US East (N. Virginia)", Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanStarter, client.CNPGClusterPlanEnterprise}},
+	{Name: "eu-west-1", ClusterProvider: client.AWSCloudProvider, Description: "Europe (Ireland)", Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanStarter, client.CNPGClusterPlanEnterprise}},
+}
+
+// DefaultPlans is the plan catalog served unless replaced with SetPlans.
+var DefaultPlans = []client.CNPGClusterPlanInfo{
+	{
+		Plan:            client.CNPGClusterPlanStarter,
+		Description:     "Shared cluster for development and small workloads.",
+		Type:            client.CNPGClusterTypeShared,
+		ServerResources: []client.ServerResource{client.ServerResourceAWST3XLarge},
+		MinDiskSize:     1,
+		MaxDiskSize:     64,
+	},
+	{
+		Plan:            client.CNPGClusterPlanEnterprise,
+		Description:     "Dedicated cluster for production workloads.",
+		Type:            client.CNPGClusterTypeDedicated,
+		ServerResources: []client.ServerResource{client.ServerResourceAWSM7ILarge, client.ServerResourceAWSR7ILarge, client.ServerResourceAWSR7IXLarge, client.ServerResourceAWSI4IXLarge},
+		MinDiskSize:     1,
+		MaxDiskSize:     16384,
+		Features:        client.CNPGClusterPlanFeatures{Pooler: true, PITR: true, HighAvailability: true},
+	},
+}
+
+// Fault makes the server fail requests of an operation.
+type Fault struct {
+	// Op is the operation to fail.
+	Op Op
+	// Status is the HTTP status of the response, e.g. 503.
+	Status int
+	// Code is the API error code sent in the body, e.g. 40400. When zero the
+	// body is empty, like the errors returned by the load balancer.
+	Code int
+	// Message is the error message sent in the body.
+	Message string
+	// RetryAfter is sent as the Retry-After header when set.
+	RetryAfter string
+	// Times is the number of requests to fail. Zero fails one request and a
+	// negative value fails all of them.
+	Times int
+}
+
+// Server is a fake PGVecto.rs Cloud API server.
+type Server struct {
+	// URL is the base URL of the server, to be passed to client.OverrideApiUrl.
+	URL string
+
+	srv *httptest.Server
+
+	mu sync.Mutex
+	// apiKey is the only API key accepted when set.
+	apiKey string
+	// settleAfter is the number of reads a cluster stays in a transitional
+	// status before it settles.
+	settleAfter int
+	clusters    map[string]*cluster
+	// order keeps the clusters in creation order for listing.
+	order           []string
+	images          []client.Image
+	serverResources []client.ServerResourceInfo
+	regions         []client.RegionInfo
+	plans           []client.CNPGClusterPlanInfo
+	idempotencyIDs  map[string]string
+	faults          []*Fault
+	calls           map[Op]int
+	paths           map[Op][]string
+	nextID          int
+}
+
+type cluster struct {
+	userID string
+	client.CNPGCluster
+	// pending counts the reads left before the cluster settles.
+	pending int
+	// deleting is set once the cluster was deleted; it disappears when it
+	// settles.
+	deleting bool
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey makes the server reject requests that do not carry apiKey.
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithSettleAfter sets the number of reads a cluster stays Initializing,
+// Upgrading or being deleted before it settles. It defaults to zero: the
+// first read after a change already sees the final status.
+func WithSettleAfter(reads int) Option {
+	return func(s *Server) {
+		s.settleAfter = reads
+	}
+}
+
+// NewServer starts a fake server. It must be closed with Close.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		clusters:        map[string]*cluster{},
+		images:          DefaultImages,
+		serverResources: DefaultServerResources,
+		regions:         DefaultRegions,
+		plans:           DefaultPlans,
+		idempotencyIDs:  map[string]string{},
+		calls:           map[Op]int{},
+		paths:           map[Op][]string{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users/{user}/cnpgs", s.handle(OpCreate, s.create))
+	mux.HandleFunc("GET /users/{user}/cnpgs", s.handle(OpList, s.list))
+	mux.HandleFunc("GET /users/{user}/cnpgs/{id}", s.handle(OpGet, s.get))
+	mux.HandleFunc("PUT /users/{user}/cnpgs/{id}/upgrade", s.handle(OpUpgrade, s.upgrade))
+	mux.HandleFunc("PUT /users/{user}/cnpgs/{id}/suspend", s.handle(OpSuspend, s.suspend))
+	mux.HandleFunc("PUT /users/{user}/cnpgs/{id}/resume", s.handle(OpResume, s.resume))
+	mux.HandleFunc("DELETE /users/{user}/cnpgs/{id}", s.handle(OpDelete, s.delete))
+	mux.HandleFunc("GET /images", s.handle(OpListImages, s.listImages))
+	mux.HandleFunc("GET /server-resources", s.handle(OpListServerResources, s.listServerResources))
+	mux.HandleFunc("GET /regions", s.handle(OpListRegions, s.listRegions))
+	mux.HandleFunc("GET /plans", s.handle(OpListPlans, s.listPlans))
+
+	s.srv = httptest.NewServer(mux)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns an HTTP client that trusts the server.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// InjectFault makes the next requests of f.Op fail. Faults are consumed in
+// the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.Times == 0 {
+		f.Times = 1
+	}
+	s.faults = append(s.faults, &f)
+}
+
+// Calls returns the number of requests received for op, failed ones included.
+func (s *Server) Calls(op Op) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[op]
+}
+
+// Paths returns the URL paths of the requests received for op, in the order
+// they were received.
+func (s *Server) Paths(op Op) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.paths[op]...)
+}
+
+// AddCluster stores a cluster as if it was created outside of Terraform, e.g.
+// in the console. The cluster is Ready unless c sets a status. An ID is
+// generated when c has none.
+func (s *Server) AddCluster(userID string, c client.CNPGCluster) client.CNPGCluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.Status.Status == "" {
+		c.Status.Status = client.CNPGClusterStatusReady
+	}
+	stored := s.store(userID, c)
+	return stored.CNPGCluster
+}
+
+// Cluster returns a stored cluster as the API would, without counting it as a
+// read.
+func (s *Server) Cluster(userID, clusterID string) (client.CNPGCluster, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clusters[clusterID]
+	if !ok || c.userID != userID {
+		return client.CNPGCluster{}, false
+	}
+	return c.CNPGCluster, true
+}
+
+// SetStatus changes the status of a stored cluster, e.g. to simulate a
+// cluster that failed. It reports whether the cluster exists.
+func (s *Server) SetStatus(userID, clusterID string, status client.ClusterStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clusters[clusterID]
+	if !ok || c.userID != userID {
+		return false
+	}
+	c.Status.Status = status
+	c.pending = 0
+	return true
+}
+
+// RemoveCluster deletes a cluster immediately, as if it was deleted outside
+// of Terraform. It reports whether the cluster existed.
+func (s *Server) RemoveCluster(userID, clusterID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clusters[clusterID]
+	if !ok || c.userID != userID {
+		return false
+	}
+	s.remove(clusterID)
+	return true
+}
+
+// SetImages replaces the image catalog served by the server.
+func (s *Server) SetImages(images []client.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.images = images
+}
+
+// SetServerResources replaces the instance type catalog served by the server.
+func (s *Server) SetServerResources(resources []client.ServerResourceInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serverResources = resources
+}
+
+// SetRegions replaces the region catalog served by the server.
+func (s *Server) SetRegions(regions []client.RegionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.regions = regions
+}
+
+// SetPlans replaces the plan catalog served by the server.
+func (s *Server) SetPlans(plans []client.CNPGClusterPlanInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plans = plans
+}
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request)
+
+// handle counts the request, checks the API key and applies injected faults
+// before calling h with the server lock held.
+func (s *Server) handle(op Op, h handlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.calls[op]++
+		s.paths[op] = append(s.paths[op], r.URL.Path)
+		w.Header().Set("X-Request-Id", fmt.Sprintf("fake-%s-%d", op, s.calls[op]))
+
+		if s.apiKey != "" && r.Header.Get("X-API-Key") != s.apiKey {
+			writeError(w, http.StatusUnauthorized, 40100, "invalid api key")
+			return
+		}
+		if f := s.takeFault(op); f != nil {
+			if f.RetryAfter != "" {
+				w.Header().Set("Retry-After", f.RetryAfter)
+			}
+			if f.Code == 0 {
+				w.WriteHeader(f.Status)
+				return
+			}
+			writeError(w, f.Status, f.Code, f.Message)
+			return
+		}
+
+		h(w, r)
+	}
+}
+
+func (s *Server) takeFault(op Op) *Fault {
+	for i, f := range s.faults {
+		if f.Op != op {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user")
+
+	key := r.Header.Get("Idempotency-Key")
+	if id, ok := s.idempotencyIDs[key]; ok && key != "" {
+		if c, ok := s.clusters[id]; ok {
+			writeJSON(w, c.CNPGCluster)
+			return
+		}
+	}
+
+	var spec client.CNPGClusterSpec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		writeError(w, http.StatusBadRequest, 40000, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if spec.Name == "" {
+		writeError(w, http.StatusBadRequest, 40000, "cluster name is required")
+		return
+	}
+	for _, c := range s.clusters {
+		if c.userID == userID && c.Spec.Name == spec.Name && !c.deleting {
+			writeError(w, http.StatusConflict, 40900, fmt.Sprintf("cluster %s already exists", spec.Name))
+			return
+		}
+	}
+
+	spec.ID = ""
+	c := s.store(userID, client.CNPGCluster{
+		Spec:   spec,
+		Status: client.CNPGClusterStatus{Status: client.CNPGClusterStatusInitializing},
+	})
+	c.pending = s.settleAfter
+	if key != "" {
+		s.idempotencyIDs[key] = c.Spec.ID
+	}
+
+	writeJSON(w, c.CNPGCluster)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("user")
+	q := r.URL.Query()
+
+	var items []client.CNPGCluster
+	for _, id := range s.order {
+		c := s.clusters[id]
+		if c.userID != userID {
+			continue
+		}
+		s.read(c)
+		if _, ok := s.clusters[id]; !ok {
+			continue
+		}
+		if !matches(c.CNPGCluster, q) {
+			continue
+		}
+		items = append(items, c.CNPGCluster)
+	}
+
+	start := 0
+	if cursor := q.Get("cursor"); cursor != "" {
+		start = len(items)
+		for i, item := range items {
+			if item.Spec.ID == cursor {
+				start = i
+				break
+			}
+		}
+	}
+	items = items[start:]
+
+	list := client.CNPGClusterList{Items: []client.CNPGCluster{}}
+	if size, err := strconv.Atoi(q.Get("page_size")); err == nil && size > 0 && size < len(items) {
+		list.NextCursor = items[size].Spec.ID
+		items = items[:size]
+	}
+	list.Items = append(list.Items, items...)
+
+	writeJSON(w, list)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	s.read(c)
+	if _, ok := s.clusters[c.Spec.ID]; !ok {
+		writeNotFound(w, c.Spec.ID)
+		return
+	}
+	writeJSON(w, c.CNPGCluster)
+}
+
+func (s *Server) upgrade(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	var req client.CNPGClusterUpgradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 40000, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if c.Status.Status != client.CNPGClusterStatusReady {
+		writeError(w, http.StatusConflict, 40901, fmt.Sprintf("cluster %s is %s", c.Spec.ID, c.Status.Status))
+		return
+	}
+
+	if req.Plan != "" {
+		c.Spec.Plan = req.Plan
+	}
+	if req.ServerResource != "" {
+		c.Spec.ServerResource = req.ServerResource
+	}
+	if req.PGDataDiskSize != "" {
+		c.Spec.PostgreSQLConfig.PGDataDiskSize = req.PGDataDiskSize
+	}
+	c.Status.Status = client.CNPGClusterStatusUpgrading
+	c.Status.UpdatedAt = now()
+	c.pending = s.settleAfter
+
+	writeJSON(w, c.CNPGCluster)
+}
+
+func (s *Server) suspend(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	if c.Status.Status != client.CNPGClusterStatusReady {
+		writeError(w, http.StatusConflict, 40901, fmt.Sprintf("cluster %s is %s", c.Spec.ID, c.Status.Status))
+		return
+	}
+
+	c.Status.Status = client.CNPGClusterStatusSuspended
+	c.Status.UpdatedAt = now()
+
+	writeJSON(w, c.CNPGCluster)
+}
+
+func (s *Server) resume(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	if c.Status.Status != client.CNPGClusterStatusSuspended {
+		writeError(w, http.StatusConflict, 40901, fmt.Sprintf("cluster %s is %s", c.Spec.ID, c.Status.Status))
+		return
+	}
+
+	c.Status.Status = client.CNPGClusterStatusResuming
+	c.Status.UpdatedAt = now()
+	c.pending = s.settleAfter
+
+	writeJSON(w, c.CNPGCluster)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+
+	c.deleting = true
+	c.pending = s.settleAfter
+	c.Status.UpdatedAt = now()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) listImages(w http.ResponseWriter, r *http.Request) {
+	list := client.ImageList{Items: []client.Image{}}
+	list.Items = append(list.Items, s.images...)
+	writeJSON(w, list)
+}
+
+func (s *Server) listServerResources(w http.ResponseWriter, r *http.Request) {
+	list := client.ServerResourceList{Items: []client.ServerResourceInfo{}}
+	list.Items = append(list.Items, s.serverResources...)
+	writeJSON(w, list)
+}
+
+func (s *Server) listRegions(w http.ResponseWriter, r *http.Request) {
+	list := client.RegionList{Items: []client.RegionInfo{}}
+	list.Items = append(list.Items, s.regions...)
+	writeJSON(w, list)
+}
+
+func (s *Server) listPlans(w http.ResponseWriter, r *http.Request) {
+	list := client.CNPGClusterPlanList{Items: []client.CNPGClusterPlanInfo{}}
+	list.Items = append(list.Items, s.plans...)
+	writeJSON(w, list)
+}
+
+// lookup returns the cluster addressed by the request path, or writes a not
+// found error.
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request) (*cluster, bool) {
+	id := r.PathValue("id")
+	c, ok := s.clusters[id]
+	if !ok || c.userID != r.PathValue("user") {
+		writeNotFound(w, id)
+		return nil, false
+	}
+	return c, true
+}
+
+// read advances the lifecycle of c by one read. Transitional statuses settle
+// once their pending reads are used up, and deleted clusters disappear.
+func (s *Server) read(c *cluster) {
+	if c.pending > 0 {
+		c.pending--
+		return
+	}
+
+	switch {
+	case c.deleting:
+		s.remove(c.Spec.ID)
+	case c.Status.Status == client.CNPGClusterStatusInitializing,
+		c.Status.Status == client.CNPGClusterStatusUpgrading,
+		c.Status.Status == client.CNPGClusterStatusResuming:
+		c.Status.Status = client.CNPGClusterStatusReady
+		c.Status.UpdatedAt = now()
+	}
+}
+
+func (s *Server) store(userID string, c client.CNPGCluster) *cluster {
+	if c.Spec.ID == "" {
+		c.Spec.ID = s.newID()
+	}
+	c.Status.ClusterID = c.Spec.ID
+	if c.Status.UpdatedAt.IsZero() {
+		c.Status.UpdatedAt = now()
+	}
+	if c.Status.Endpoint.VectorUserEndpoint == "" {
+		c.Status.Endpoint.VectorUserEndpoint = fmt.Sprintf("postgres://%s.fake.pgvecto.rs:5432/%s", c.Spec.Name, c.Spec.PostgreSQLConfig.VectorConfig.DatabaseName)
+	}
+	if c.Spec.PostgreSQLConfig.EnablePooler && c.Status.Endpoint.PoolerUserEndpoint == "" {
+		c.Status.Endpoint.PoolerUserEndpoint = fmt.Sprintf("postgres://%s-pooler.fake.pgvecto.rs:5432/%s", c.Spec.Name, c.Spec.PostgreSQLConfig.VectorConfig.DatabaseName)
+	}
+
+	stored := &cluster{userID: userID, CNPGCluster: c}
+	if _, ok := s.clusters[c.Spec.ID]; !ok {
+		s.order = append(s.order, c.Spec.ID)
+	}
+	s.clusters[c.Spec.ID] = stored
+	return stored
+}
+
+func (s *Server) remove(id string) {
+	delete(s.clusters, id)
+	for i, o := range s.order {
+		if o == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// newID returns a random UUID like the ones generated by the API.
+func (s *Server) newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		s.nextID++
+		return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func matches(c client.CNPGCluster, q map[string][]string) bool {
+	get := func(key string) string {
+		if v := q[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	if v := get("status"); v != "" && string(c.Status.Status) != v {
+		return false
+	}
+	if v := get("region"); v != "" && c.Spec.ClusterProvider.Region != v {
+		return false
+	}
+	if v := get("plan"); v != "" && string(c.Spec.Plan) != v {
+		return false
+	}
+	if v := get("name_prefix"); v != "" && !strings.HasPrefix(c.Spec.Name, v) {
+		return false
+	}
+	return true
+}
+
+// now returns the current time truncated to seconds, as returned by the API.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(client.Error{HTTPStatusCode: code, Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, 40400, fmt.Sprintf("cluster %s not found", id))
+}
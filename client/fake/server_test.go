@@ -0,0 +1,183 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+func newTestClient(t *testing.T, srv *Server) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(
+		client.WithApiKey("test"),
+		client.OverrideApiUrl(srv.URL),
+		client.WithRetryWait(time.Millisecond, 5*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	srv := NewServer(WithSettleAfter(1))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	created, err := c.CreateCluster(ctx, client.CNPGClusterSpec{Name: "test", Plan: client.CNPGClusterPlanStarter}, "user")
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	if created.Status.Status != client.CNPGClusterStatusInitializing {
+		t.Errorf("CreateCluster() status = %s, want %s", created.Status.Status, client.CNPGClusterStatusInitializing)
+	}
+
+	for _, want := range []client.ClusterStatus{client.CNPGClusterStatusInitializing, client.CNPGClusterStatusReady} {
+		got, err := c.GetCluster(ctx, "user", created.Spec.ID)
+		if err != nil {
+			t.Fatalf("GetCluster() error = %v", err)
+		}
+		if got.Status.Status != want {
+			t.Errorf("GetCluster() status = %s, want %s", got.Status.Status, want)
+		}
+	}
+
+	upgraded, err := c.UpgradeCluster(ctx, "user", created.Spec.ID, client.CNPGClusterUpgradeRequest{PGDataDiskSize: "20"})
+	if err != nil {
+		t.Fatalf("UpgradeCluster() error = %v", err)
+	}
+	if upgraded.Status.Status != client.CNPGClusterStatusUpgrading {
+		t.Errorf("UpgradeCluster() status = %s, want %s", upgraded.Status.Status, client.CNPGClusterStatusUpgrading)
+	}
+	if upgraded.Spec.PostgreSQLConfig.PGDataDiskSize != "20" {
+		t.Errorf("UpgradeCluster() disk size = %s, want 20", upgraded.Spec.PostgreSQLConfig.PGDataDiskSize)
+	}
+
+	if err := c.DeleteCluster(ctx, "user", created.Spec.ID); err != nil {
+		t.Fatalf("DeleteCluster() error = %v", err)
+	}
+	if _, err := c.GetCluster(ctx, "user", created.Spec.ID); err != nil {
+		t.Fatalf("GetCluster() while deleting error = %v", err)
+	}
+	if _, err := c.GetCluster(ctx, "user", created.Spec.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetCluster() after delete error = %v, want %v", err, client.ErrNotFound)
+	}
+
+	want := "/users/user/cnpgs/" + created.Spec.ID
+	if got := srv.Paths(OpDelete); len(got) != 1 || got[0] != want {
+		t.Errorf("Paths(OpDelete) = %q, want [%q]", got, want)
+	}
+}
+
+func TestServer_SuspendResume(t *testing.T) {
+	srv := NewServer(WithSettleAfter(1))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+	ctx := context.Background()
+
+	cluster := srv.AddCluster("user", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: "test"}})
+
+	if _, err := c.ResumeCluster(ctx, "user", cluster.Spec.ID); !errors.Is(err, client.ErrConflict) {
+		t.Errorf("ResumeCluster() of a Ready cluster error = %v, want %v", err, client.ErrConflict)
+	}
+
+	suspended, err := c.SuspendCluster(ctx, "user", cluster.Spec.ID)
+	if err != nil {
+		t.Fatalf("SuspendCluster() error = %v", err)
+	}
+	if suspended.Status.Status != client.CNPGClusterStatusSuspended {
+		t.Errorf("SuspendCluster() status = %s, want %s", suspended.Status.Status, client.CNPGClusterStatusSuspended)
+	}
+	if _, err := c.UpgradeCluster(ctx, "user", cluster.Spec.ID, client.CNPGClusterUpgradeRequest{PGDataDiskSize: "20"}); !errors.Is(err, client.ErrConflict) {
+		t.Errorf("UpgradeCluster() of a Suspended cluster error = %v, want %v", err, client.ErrConflict)
+	}
+
+	resumed, err := c.ResumeCluster(ctx, "user", cluster.Spec.ID)
+	if err != nil {
+		t.Fatalf("ResumeCluster() error = %v", err)
+	}
+	if resumed.Status.Status != client.CNPGClusterStatusResuming {
+		t.Errorf("ResumeCluster() status = %s, want %s", resumed.Status.Status, client.CNPGClusterStatusResuming)
+	}
+	for _, want := range []client.ClusterStatus{client.CNPGClusterStatusResuming, client.CNPGClusterStatusReady} {
+		got, err := c.GetCluster(ctx, "user", cluster.Spec.ID)
+		if err != nil {
+			t.Fatalf("GetCluster() error = %v", err)
+		}
+		if got.Status.Status != want {
+			t.Errorf("GetCluster() status = %s, want %s", got.Status.Status, want)
+		}
+	}
+}
+
+func TestServer_CreateConflict(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	srv.AddCluster("user", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: "test"}})
+
+	_, err := c.CreateCluster(context.Background(), client.CNPGClusterSpec{Name: "test"}, "user")
+	if !errors.Is(err, client.ErrConflict) {
+		t.Errorf("CreateCluster() error = %v, want %v", err, client.ErrConflict)
+	}
+}
+
+func TestServer_ListPages(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	for _, name := range []string{"a", "b", "c"} {
+		srv.AddCluster("user", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: name, Plan: client.CNPGClusterPlanStarter}})
+	}
+	srv.AddCluster("user", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: "d", Plan: client.CNPGClusterPlanEnterprise}})
+	srv.AddCluster("other", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: "e", Plan: client.CNPGClusterPlanStarter}})
+
+	clusters, err := c.ListAllClusters(context.Background(), "user", client.ListClustersOptions{Plan: client.CNPGClusterPlanStarter, PageSize: 2})
+	if err != nil {
+		t.Fatalf("ListAllClusters() error = %v", err)
+	}
+	if len(clusters) != 3 {
+		t.Errorf("ListAllClusters() = %d clusters, want 3", len(clusters))
+	}
+	if got := srv.Calls(OpList); got != 2 {
+		t.Errorf("ListAllClusters() requests = %d, want 2", got)
+	}
+}
+
+func TestServer_InjectFault(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	cluster := srv.AddCluster("user", client.CNPGCluster{Spec: client.CNPGClusterSpec{Name: "test"}})
+
+	srv.InjectFault(Fault{Op: OpGet, Status: http.StatusServiceUnavailable, Times: 2})
+	if _, err := c.GetCluster(context.Background(), "user", cluster.Spec.ID); err != nil {
+		t.Fatalf("GetCluster() error = %v", err)
+	}
+	if got := srv.Calls(OpGet); got != 3 {
+		t.Errorf("GetCluster() requests = %d, want 3", got)
+	}
+
+	srv.InjectFault(Fault{Op: OpDelete, Status: http.StatusForbidden, Code: 40005, Message: "permission denied"})
+	if err := c.DeleteCluster(context.Background(), "user", cluster.Spec.ID); !errors.Is(err, client.ErrPermissionDenied) {
+		t.Errorf("DeleteCluster() error = %v, want %v", err, client.ErrPermissionDenied)
+	}
+}
+
+func TestServer_APIKey(t *testing.T) {
+	srv := NewServer(WithAPIKey("secret"))
+	defer srv.Close()
+	c := newTestClient(t, srv)
+
+	if _, err := c.GetCluster(context.Background(), "user", "cluster"); !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("GetCluster() error = %v, want %v", err, client.ErrUnauthorized)
+	}
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random UUID (version 4) used to deduplicate
+// retried requests on the API side.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate idempotency key: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
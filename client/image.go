@@ -0,0 +1,301 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ImageExtension string
+
+const (
+	// ImageExtensionPGVectoRS is the pgvecto.rs extension, shipped in the
+	// modelzai/pgvecto-rs images.
+	ImageExtensionPGVectoRS ImageExtension = "pgvecto.rs"
+	// ImageExtensionVectorChord is the VectorChord extension, shipped in the
+	// modelzai/vchord-cnpg images.
+	ImageExtensionVectorChord ImageExtension = "vectorchord"
+)
+
+// imageTagPattern matches image tags such as 16-v0.4.0-extensions-exts: the
+// PostgreSQL major version, the extension version and optional suffixes.
+var imageTagPattern = regexp.MustCompile(`^(\d+)-(v\d+\.\d+\.\d+)((?:-[a-zA-Z]+(?:-[a-zA-Z]+)?)?)$`)
+
+// Image is an image that clusters can be created with.
+type Image struct {
+	// Tag is the image tag to set as the cluster image, e.g. 16-v0.4.0-exts.
+	Tag string `json:"tag"`
+	// Image is the full image reference, e.g. modelzai/pgvecto-rs:16-v0.4.0-exts.
+	Image string `json:"image,omitempty"`
+	// PGMajorVersion is the PostgreSQL major version, e.g. 16.
+	PGMajorVersion int `json:"pg_major_version,omitempty"`
+	// Extension is the vector extension shipped in the image.
+	Extension ImageExtension `json:"extension,omitempty"`
+	// ExtensionVersion is the version of the extension, e.g. v0.4.0.
+	ExtensionVersion string `json:"extension_version,omitempty"`
+	// Deprecated images can still be used but will be removed.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// UnmarshalJSON accepts an image object or a bare tag, and fills the fields
+// that the API left out from the tag.
+func (i *Image) UnmarshalJSON(b []byte) error {
+	var tag string
+	if err := json.Unmarshal(b, &tag); err == nil {
+		*i = Image{Tag: tag}
+	} else {
+		type image Image
+		var v image
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		*i = Image(v)
+	}
+
+	if i.Tag == "" && i.Image != "" {
+		if ref, err := ParseImageReference(i.Image); err == nil {
+			i.Tag = ref.Tag
+		}
+	}
+	if parsed, err := ParseImageTag(i.Tag); err == nil {
+		if i.Image == "" {
+			i.Image = parsed.Image
+		}
+		if i.PGMajorVersion == 0 {
+			i.PGMajorVersion = parsed.PGMajorVersion
+		}
+		if i.Extension == "" {
+			i.Extension = parsed.Extension
+		}
+		if i.ExtensionVersion == "" {
+			i.ExtensionVersion = parsed.ExtensionVersion
+		}
+	}
+	return nil
+}
+
+// ParseImageTag returns the image described by a tag such as
+// 16-v0.4.0-extensions-exts. Tags containing exts are pgvecto.rs images, the
+// others are VectorChord images.
+func ParseImageTag(tag string) (Image, error) {
+	m := imageTagPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return Image{}, fmt.Errorf("invalid image tag %q, expected a tag such as 16-v0.4.0-exts", tag)
+	}
+
+	pgMajor, err := strconv.Atoi(m[1])
+	if err != nil {
+		return Image{}, fmt.Errorf("invalid image tag %q: %w", tag, err)
+	}
+
+	image := Image{
+		Tag:              tag,
+		PGMajorVersion:   pgMajor,
+		ExtensionVersion: m[2],
+		Extension:        ImageExtensionVectorChord,
+	}
+	if strings.Contains(m[3], "exts") {
+		image.Extension = ImageExtensionPGVectoRS
+	}
+	image.Image = image.Extension.DefaultRepository() + ":" + tag
+	return image, nil
+}
+
+type ImageList struct {
+	// Items is the list of the images.
+	Items []Image `json:"items"`
+}
+
+// UnmarshalJSON accepts a list object or a bare array of images.
+func (l *ImageList) UnmarshalJSON(b []byte) error {
+	var items []Image
+	if err := json.Unmarshal(b, &items); err == nil {
+		l.Items = items
+		return nil
+	}
+
+	type list ImageList
+	var v list
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*l = ImageList(v)
+	return nil
+}
+
+// ListImages returns the images clusters can be created with.
+func (c *Client) ListImages(ctx context.Context) ([]Image, error) {
+	var list ImageList
+	err := c.do(ctx, "GET", "images", nil, &list)
+	return list.Items, err
+}
+
+// LatestImage returns the newest image that is not deprecated, among the
+// images of the given PostgreSQL major version and extension. A zero
+// pgMajor or an empty extension matches every image. It returns false when
+// no image matches.
+func LatestImage(images []Image, pgMajor int, extension ImageExtension) (Image, bool) {
+	var latest Image
+	var found bool
+	for _, image := range images {
+		if image.Deprecated ||
+			(pgMajor != 0 && image.PGMajorVersion != pgMajor) ||
+			(extension != "" && image.Extension != extension) {
+			continue
+		}
+		if !found || image.newerThan(latest) {
+			latest, found = image, true
+		}
+	}
+	return latest, found
+}
+
+// newerThan orders images by PostgreSQL major version, then extension, with
+// VectorChord, the successor of pgvecto.rs, first, then extension version,
+// then tag.
+func (i Image) newerThan(other Image) bool {
+	if i.PGMajorVersion != other.PGMajorVersion {
+		return i.PGMajorVersion > other.PGMajorVersion
+	}
+	if i.Extension != other.Extension {
+		return i.Extension == ImageExtensionVectorChord
+	}
+	if cmp := compareVersions(i.ExtensionVersion, other.ExtensionVersion); cmp != 0 {
+		return cmp > 0
+	}
+	return i.Tag > other.Tag
+}
+
+// compareVersions compares versions such as v0.4.0 numerically.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x > y {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
+
+// DefaultRepository returns the repository of the images shipping extension,
+// or an empty string for an unknown extension.
+func (e ImageExtension) DefaultRepository() string {
+	switch e {
+	case ImageExtensionPGVectoRS:
+		return "modelzai/pgvecto-rs"
+	case ImageExtensionVectorChord:
+		return "modelzai/vchord-cnpg"
+	default:
+		return ""
+	}
+}
+
+// ImageReference is a parsed container image reference such as
+// registry.example.com:5000/modelzai/vchord-cnpg:17-v0.2.0@sha256:<hex>.
+type ImageReference struct {
+	// Registry is the registry host, with its port, or empty for Docker Hub.
+	Registry string
+	// Repository is the path of the image in the registry, e.g.
+	// modelzai/vchord-cnpg.
+	Repository string
+	// Tag is the tag of the image, e.g. 17-v0.2.0. It may be empty when
+	// Digest is set.
+	Tag string
+	// Digest pins the image content, e.g. sha256:<hex>.
+	Digest string
+}
+
+var (
+	imageRepositoryPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	imageReferenceTagRegex = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigestPattern     = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+)
+
+// ParseImageReference parses an image reference. Unlike a plain split on
+// ":", it handles registries with ports and references pinned by digest.
+// The reference must have a tag or a digest.
+func ParseImageReference(s string) (ImageReference, error) {
+	var ref ImageReference
+	rest := s
+
+	if i := strings.Index(rest, "@"); i >= 0 {
+		ref.Digest = rest[i+1:]
+		rest = rest[:i]
+		if !imageDigestPattern.MatchString(ref.Digest) {
+			return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid digest %q", s, ref.Digest)
+		}
+	}
+
+	// A colon after the last slash separates the tag, other colons belong
+	// to the registry port.
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		ref.Tag = rest[i+1:]
+		rest = rest[:i]
+		if !imageReferenceTagRegex.MatchString(ref.Tag) {
+			return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid tag %q", s, ref.Tag)
+		}
+	}
+
+	// The first component is a registry when it looks like a host.
+	if i := strings.Index(rest, "/"); i >= 0 {
+		if host := rest[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry = host
+			rest = rest[i+1:]
+		}
+	}
+	ref.Repository = rest
+
+	if !imageRepositoryPattern.MatchString(ref.Repository) {
+		return ImageReference{}, fmt.Errorf("invalid image reference %q: invalid repository %q", s, ref.Repository)
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		return ImageReference{}, fmt.Errorf("invalid image reference %q: a tag or a digest is required", s)
+	}
+	return ref, nil
+}
+
+// String returns the reference in its canonical form.
+func (r ImageReference) String() string {
+	s := r.Repository
+	if r.Registry != "" {
+		s = r.Registry + "/" + s
+	}
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// Extension returns the extension shipped in the image, guessed from its
+// repository name and then from its tag. It returns an empty string when it
+// cannot be guessed.
+func (r ImageReference) Extension() ImageExtension {
+	name := r.Repository[strings.LastIndex(r.Repository, "/")+1:]
+	switch {
+	case strings.Contains(name, "vchord"), strings.Contains(name, "vectorchord"):
+		return ImageExtensionVectorChord
+	case strings.Contains(name, "pgvecto"):
+		return ImageExtensionPGVectoRS
+	}
+	if image, err := ParseImageTag(r.Tag); err == nil {
+		return image.Extension
+	}
+	return ""
+}
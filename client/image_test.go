@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseImageTag(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    Image
+		wantErr bool
+	}{
+		{
+			tag: "16-v0.4.0-extensions-exts",
+			want: Image{
+				Tag:              "16-v0.4.0-extensions-exts",
+				Image:            "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts",
+				PGMajorVersion:   16,
+				Extension:        ImageExtensionPGVectoRS,
+				ExtensionVersion: "v0.4.0",
+			},
+		},
+		{
+			tag: "17-v0.2.0",
+			want: Image{
+				Tag:              "17-v0.2.0",
+				Image:            "modelzai/vchord-cnpg:17-v0.2.0",
+				PGMajorVersion:   17,
+				Extension:        ImageExtensionVectorChord,
+				ExtensionVersion: "v0.2.0",
+			},
+		},
+		{tag: "latest", wantErr: true},
+		{tag: "modelzai/vchord-cnpg:17-v0.2.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseImageTag(tt.tag)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseImageTag(%q) error = %v, wantErr %v", tt.tag, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseImageTag(%q) = %+v, want %+v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		ref     string
+		want    ImageReference
+		wantErr bool
+	}{
+		{
+			ref:  "modelzai/vchord-cnpg:17-v0.2.0",
+			want: ImageReference{Repository: "modelzai/vchord-cnpg", Tag: "17-v0.2.0"},
+		},
+		{
+			ref:  "registry.example.com:5000/modelzai/pgvecto-rs:16-v0.4.0-extensions-exts",
+			want: ImageReference{Registry: "registry.example.com:5000", Repository: "modelzai/pgvecto-rs", Tag: "16-v0.4.0-extensions-exts"},
+		},
+		{
+			ref:  "localhost/vchord:17-v0.2.0@" + digest,
+			want: ImageReference{Registry: "localhost", Repository: "vchord", Tag: "17-v0.2.0", Digest: digest},
+		},
+		{
+			ref:  "modelzai/vchord-cnpg@" + digest,
+			want: ImageReference{Repository: "modelzai/vchord-cnpg", Digest: digest},
+		},
+		{ref: "registry.example.com:5000/modelzai/vchord-cnpg", wantErr: true},
+		{ref: "modelzai/vchord-cnpg:17-v0.2.0@sha256:abc", wantErr: true},
+		{ref: "Modelzai/vchord-cnpg:17-v0.2.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseImageReference(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseImageReference(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseImageReference(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+		if got.String() != tt.ref {
+			t.Errorf("ParseImageReference(%q).String() = %q", tt.ref, got.String())
+		}
+	}
+}
+
+func TestClient_ListImages(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "objects", body: `{"items":[{"tag":"16-v0.4.0-extensions-exts","deprecated":true},{"image":"modelzai/vchord-cnpg:17-v0.2.0"}]}`},
+		{name: "tags", body: `["16-v0.4.0-extensions-exts","17-v0.2.0"]`},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/images" {
+				t.Errorf("%s: path = %q, want %q", tt.name, r.URL.Path, "/images")
+			}
+			_, _ = w.Write([]byte(tt.body))
+		}))
+
+		c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+		images, err := c.ListImages(context.Background())
+		srv.Close()
+		if err != nil {
+			t.Fatalf("%s: ListImages() error = %v", tt.name, err)
+		}
+
+		if len(images) != 2 {
+			t.Fatalf("%s: ListImages() returned %d images, want 2", tt.name, len(images))
+		}
+		if got := images[0]; got.PGMajorVersion != 16 || got.Extension != ImageExtensionPGVectoRS || got.Image != "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts" {
+			t.Errorf("%s: images[0] = %+v", tt.name, got)
+		}
+		if got := images[1]; got.Tag != "17-v0.2.0" || got.Extension != ImageExtensionVectorChord || got.ExtensionVersion != "v0.2.0" {
+			t.Errorf("%s: images[1] = %+v", tt.name, got)
+		}
+	}
+}
+
+func TestLatestImage(t *testing.T) {
+	var images []Image
+	for _, tag := range []string{"16-v0.3.0-extensions-exts", "16-v0.10.0-extensions-exts", "16-v0.2.0", "17-v0.1.0", "17-v0.2.0"} {
+		image, err := ParseImageTag(tag)
+		if err != nil {
+			t.Fatalf("ParseImageTag(%q) error = %v", tag, err)
+		}
+		images = append(images, image)
+	}
+	images[4].Deprecated = true
+
+	tests := []struct {
+		pgMajor   int
+		extension ImageExtension
+		want      string
+	}{
+		{want: "17-v0.1.0"},
+		{pgMajor: 16, want: "16-v0.2.0"},
+		{pgMajor: 16, extension: ImageExtensionPGVectoRS, want: "16-v0.10.0-extensions-exts"},
+		{pgMajor: 17, extension: ImageExtensionPGVectoRS},
+		{pgMajor: 15},
+	}
+	for _, tt := range tests {
+		got, ok := LatestImage(images, tt.pgMajor, tt.extension)
+		if ok != (tt.want != "") || got.Tag != tt.want {
+			t.Errorf("LatestImage(%d, %q) = %q, %v, want %q", tt.pgMajor, tt.extension, got.Tag, ok, tt.want)
+		}
+	}
+}
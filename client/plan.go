@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"slices"
+)
+
+// CNPGClusterPlanFeatures are the capabilities of the clusters of a plan.
+type CNPGClusterPlanFeatures struct {
+	// Pooler is whether clusters can enable the connection pooler.
+	Pooler bool `json:"pooler"`
+	// PITR is whether clusters can be restored to a point in time.
+	PITR bool `json:"pitr"`
+	// HighAvailability is whether clusters run with standby instances.
+	HighAvailability bool `json:"high_availability"`
+}
+
+// AllowsServerResource reports whether clusters of the plan can run on
+// resource.
+func (p CNPGClusterPlanInfo) AllowsServerResource(resource ServerResource) bool {
+	return slices.Contains(p.ServerResources, resource)
+}
+
+type CNPGClusterPlanList struct {
+	// Items is the list of the plans.
+	Items []CNPGClusterPlanInfo `json:"items"`
+}
+
+// ListPlans returns the plans clusters can be created with.
+func (c *Client) ListPlans(ctx context.Context) ([]CNPGClusterPlanInfo, error) {
+	var list CNPGClusterPlanList
+	err := c.do(ctx, "GET", "plans", nil, &list)
+	return list.Items, err
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListPlans(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/plans" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/plans")
+		}
+		_, _ = w.Write([]byte(`{"items":[{"plan":"Enterprise","description":"Dedicated","type":"Dedicated","server_resources":["aws-m7i-large-2c-8g"],"min_disk_size":1,"max_disk_size":16384,"features":{"pooler":true,"pitr":true,"high_availability":false}}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	plans, err := c.ListPlans(context.Background())
+	if err != nil {
+		t.Fatalf("ListPlans() error = %v", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("ListPlans() returned %d plans, want 1", len(plans))
+	}
+
+	got := plans[0]
+	if got.Plan != CNPGClusterPlanEnterprise || got.Type != CNPGClusterTypeDedicated || got.MaxDiskSize != 16384 {
+		t.Errorf("plan = %+v", got)
+	}
+	if want := (CNPGClusterPlanFeatures{Pooler: true, PITR: true}); got.Features != want {
+		t.Errorf("features = %+v, want %+v", got.Features, want)
+	}
+	if !got.AllowsServerResource(ServerResourceAWSM7ILarge) || got.AllowsServerResource(ServerResourceAWST3XLarge) {
+		t.Errorf("AllowsServerResource() does not match server resources %v", got.ServerResources)
+	}
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried after the first
+	// attempt. Zero disables retries.
+	MaxRetries int
+	// WaitMin is the backoff before the first retry.
+	WaitMin time.Duration
+	// WaitMax caps the exponential backoff between retries.
+	WaitMax time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 4,
+	WaitMin:    1 * time.Second,
+	WaitMax:    30 * time.Second,
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+func WithMaxRetries(maxRetries int) Option {
+	return func(c *Client) {
+		policy := c.currentRetryPolicy()
+		policy.MaxRetries = maxRetries
+		c.retryPolicy = &policy
+	}
+}
+
+func WithRetryWait(waitMin, waitMax time.Duration) Option {
+	return func(c *Client) {
+		policy := c.currentRetryPolicy()
+		policy.WaitMin = waitMin
+		policy.WaitMax = waitMax
+		c.retryPolicy = &policy
+	}
+}
+
+func WithDefaultRetryPolicy() Option {
+	return func(c *Client) {
+		if c.retryPolicy == nil {
+			policy := DefaultRetryPolicy
+			c.retryPolicy = &policy
+		}
+	}
+}
+
+func (c *Client) currentRetryPolicy() RetryPolicy {
+	if c.retryPolicy == nil {
+		return DefaultRetryPolicy
+	}
+	return *c.retryPolicy
+}
+
+// sendWithRetry sends req and retries it according to the client retry
+// policy. The returned response body must be closed by the caller.
+func (c *Client) sendWithRetry(req *http.Request) (*http.Response, error) {
+	var policy RetryPolicy
+	if c.retryPolicy != nil {
+		policy = *c.retryPolicy
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err := c.HttpClient.Do(attemptReq)
+		if attempt >= policy.MaxRetries || !shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := policy.backoff(attempt, res)
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body so that it can be
+// sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// shouldRetry reports whether the outcome of a request is worth retrying.
+// Requests that are not idempotent are only retried when the server
+// explicitly rejected them before doing any work.
+func shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return isIdempotent(req)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	default:
+		return false
+	}
+}
+
+// isIdempotent reports whether req can be replayed safely. Requests carrying
+// an idempotency key are deduplicated by the API.
+func isIdempotent(req *http.Request) bool {
+	if req.Header.Get(idempotencyKeyHeader) != "" {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the next attempt. A Retry-After header
+// sent by the server takes precedence over the exponential backoff, but is
+// still capped by WaitMax so that a server cannot stall a request for longer.
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > p.WaitMax {
+				wait = p.WaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := p.WaitMin << uint(attempt)
+	if wait <= 0 || wait > p.WaitMax {
+		wait = p.WaitMax
+	}
+	if wait <= 0 {
+		return 0
+	}
+
+	// Jitter the wait between half and the whole backoff.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(wait-half)+1))
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
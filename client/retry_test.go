@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(
+		WithApiKey("test"),
+		OverrideApiUrl(srv.URL),
+		WithMaxRetries(3),
+		WithRetryWait(time.Millisecond, 5*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return c
+}
+
+func TestClient_RetryIdempotentRequest(t *testing.T) {
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"spec":{"id":"cluster"}}`))
+	})
+
+	cluster, err := c.GetCluster(context.Background(), "user", "cluster")
+	if err != nil {
+		t.Fatalf("GetCluster() error = %v", err)
+	}
+	if cluster.Spec.ID != "cluster" {
+		t.Errorf("GetCluster() id = %q, want %q", cluster.Spec.ID, "cluster")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("GetCluster() calls = %d, want 3", got)
+	}
+}
+
+func TestClient_RetryReplaysBody(t *testing.T) {
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 {
+			t.Errorf("attempt %d sent an empty body", atomic.LoadInt32(&calls)+1)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"spec":{"id":"cluster"}}`))
+	})
+
+	_, err := c.UpgradeCluster(context.Background(), "user", "cluster", CNPGClusterUpgradeRequest{PGDataDiskSize: "10"})
+	if err != nil {
+		t.Fatalf("UpgradeCluster() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("UpgradeCluster() calls = %d, want 2", got)
+	}
+}
+
+func TestClient_RetryCreateWithIdempotencyKey(t *testing.T) {
+	var calls int32
+	var keys []string
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"spec":{"id":"cluster"}}`))
+	})
+
+	_, err := c.CreateCluster(context.Background(), CNPGClusterSpec{Name: "test"}, "user")
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("CreateCluster() idempotency keys = %q, want the same non-empty key twice", keys)
+	}
+}
+
+func TestShouldRetry(t *testing.T) {
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	tooMany := &http.Response{StatusCode: http.StatusTooManyRequests}
+
+	post := httptest.NewRequest(http.MethodPost, "/users/user/cnpgs", nil)
+	if shouldRetry(post, unavailable, nil) {
+		t.Errorf("shouldRetry(POST, 503) = true, want false")
+	}
+	if !shouldRetry(post, tooMany, nil) {
+		t.Errorf("shouldRetry(POST, 429) = false, want true")
+	}
+
+	keyed := httptest.NewRequest(http.MethodPost, "/users/user/cnpgs", nil)
+	keyed.Header.Set(idempotencyKeyHeader, "key")
+	if !shouldRetry(keyed, unavailable, nil) {
+		t.Errorf("shouldRetry(POST with idempotency key, 503) = false, want true")
+	}
+
+	get := httptest.NewRequest(http.MethodGet, "/users/user/cnpgs/cluster", nil)
+	if !shouldRetry(get, unavailable, nil) {
+		t.Errorf("shouldRetry(GET, 503) = false, want true")
+	}
+	if shouldRetry(get, &http.Response{StatusCode: http.StatusNotFound}, nil) {
+		t.Errorf("shouldRetry(GET, 404) = true, want false")
+	}
+	if shouldRetry(get, nil, context.Canceled) {
+		t.Errorf("shouldRetry(GET, context.Canceled) = true, want false")
+	}
+}
+
+func TestClient_RetryCreateOnTooManyRequests(t *testing.T) {
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"spec":{"id":"cluster"}}`))
+	})
+
+	_, err := c.CreateCluster(context.Background(), CNPGClusterSpec{Name: "test"}, "user")
+	if err != nil {
+		t.Fatalf("CreateCluster() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("CreateCluster() calls = %d, want 2", got)
+	}
+}
+
+func TestClient_RetryAfterCappedByWaitMax(t *testing.T) {
+	var calls int32
+	c := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"spec":{"id":"cluster"}}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.GetCluster(ctx, "user", "cluster"); err != nil {
+		t.Fatalf("GetCluster() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("GetCluster() calls = %d, want 2", got)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{WaitMin: time.Second, WaitMax: 30 * time.Second}
+	tests := []struct {
+		retryAfter string
+		want       time.Duration
+	}{
+		{retryAfter: "0", want: 0},
+		{retryAfter: "10", want: 10 * time.Second},
+		{retryAfter: "3600", want: 30 * time.Second},
+	}
+	for _, tt := range tests {
+		res := &http.Response{Header: http.Header{"Retry-After": []string{tt.retryAfter}}}
+		if got := policy.backoff(0, res); got != tt.want {
+			t.Errorf("backoff(Retry-After: %s) = %v, want %v", tt.retryAfter, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "3", want: 3 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: "Mon, 02 Jan 2006 15:04:05 GMT", want: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"slices"
+)
+
+// ServerResourceInfo describes an instance type clusters can run on.
+type ServerResourceInfo struct {
+	// Name is the server resource to set on a cluster, e.g.
+	// aws-m7i-large-2c-8g.
+	Name ServerResource `json:"name"`
+	// ClusterProvider is the cloud provider of the instance type.
+	ClusterProvider ClusterProviderType `json:"cluster_provider,omitempty"`
+	// VCPU is the number of virtual CPUs.
+	VCPU int `json:"vcpu"`
+	// MemoryGiB is the memory in GiB.
+	MemoryGiB int `json:"memory_gib"`
+	// PricePerHour is the price of a cluster instance in USD per hour.
+	PricePerHour float64 `json:"price_per_hour"`
+	// Plans are the plans the instance type is available with.
+	Plans []CNPGClusterPlan `json:"plans"`
+	// Regions are the regions the instance type is available in.
+	Regions []string `json:"regions"`
+}
+
+// AvailableWith reports whether the instance type can be used with plan.
+func (r ServerResourceInfo) AvailableWith(plan CNPGClusterPlan) bool {
+	return slices.Contains(r.Plans, plan)
+}
+
+// AvailableIn reports whether the instance type is available in region.
+func (r ServerResourceInfo) AvailableIn(region string) bool {
+	return slices.Contains(r.Regions, region)
+}
+
+type ServerResourceList struct {
+	// Items is the list of the server resources.
+	Items []ServerResourceInfo `json:"items"`
+}
+
+// ListServerResources returns the instance types clusters can run on.
+func (c *Client) ListServerResources(ctx context.Context) ([]ServerResourceInfo, error) {
+	var list ServerResourceList
+	err := c.do(ctx, "GET", "server-resources", nil, &list)
+	return list.Items, err
+}
+
+// RegionInfo describes a region clusters can be created in.
+type RegionInfo struct {
+	// Name is the region to set on a cluster, e.g. us-east-1.
+	Name string `json:"name"`
+	// ClusterProvider is the cloud provider of the region.
+	ClusterProvider ClusterProviderType `json:"cluster_provider,omitempty"`
+	// Description is the location of the region, e.g. US East (N. Virginia).
+	Description string `json:"description,omitempty"`
+	// Plans are the plans available in the region.
+	Plans []CNPGClusterPlan `json:"plans"`
+}
+
+// AvailableWith reports whether clusters of plan can be created in the
+// region.
+func (r RegionInfo) AvailableWith(plan CNPGClusterPlan) bool {
+	return slices.Contains(r.Plans, plan)
+}
+
+type RegionList struct {
+	// Items is the list of the regions.
+	Items []RegionInfo `json:"items"`
+}
+
+// ListRegions returns the regions clusters can be created in.
+func (c *Client) ListRegions(ctx context.Context) ([]RegionInfo, error) {
+	var list RegionList
+	err := c.do(ctx, "GET", "regions", nil, &list)
+	return list.Items, err
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListServerResources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/server-resources" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/server-resources")
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"aws-i4i-xlarge-4c-32g","cluster_provider":"aws","vcpu":4,"memory_gib":32,"price_per_hour":0.343,"plans":["Enterprise"],"regions":["us-east-1"]}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	resources, err := c.ListServerResources(context.Background())
+	if err != nil {
+		t.Fatalf("ListServerResources() error = %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("ListServerResources() returned %d server resources, want 1", len(resources))
+	}
+
+	got := resources[0]
+	if got.Name != ServerResourceAWSI4IXLarge || got.VCPU != 4 || got.MemoryGiB != 32 || got.PricePerHour != 0.343 {
+		t.Errorf("server resource = %+v", got)
+	}
+	if !got.AvailableWith(CNPGClusterPlanEnterprise) || got.AvailableWith(CNPGClusterPlanStarter) {
+		t.Errorf("AvailableWith() does not match plans %v", got.Plans)
+	}
+	if !got.AvailableIn("us-east-1") || got.AvailableIn("eu-west-1") {
+		t.Errorf("AvailableIn() does not match regions %v", got.Regions)
+	}
+}
+
+func TestClient_ListRegions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/regions" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/regions")
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"eu-west-1","cluster_provider":"aws","description":"Europe (Ireland)","plans":["Enterprise"]}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(WithApiKey("test"), OverrideApiUrl(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	regions, err := c.ListRegions(context.Background())
+	if err != nil {
+		t.Fatalf("ListRegions() error = %v", err)
+	}
+	if len(regions) != 1 || regions[0].Name != "eu-west-1" || regions[0].Description != "Europe (Ireland)" {
+		t.Fatalf("ListRegions() = %+v", regions)
+	}
+	if regions[0].AvailableWith(CNPGClusterPlanStarter) {
+		t.Errorf("eu-west-1 is available with Starter, want only Enterprise")
+	}
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    pgvecto-rs-cloud = {
+      source = "tensorchord/pgvecto-rs-cloud"
+    }
+  }
+}
+
+provider "pgvecto-rs-cloud" {
+  api_key = "pgrs-xxxxxxxxxxxxxxxx"
+}
+
+data "pgvecto-rs-cloud_clusters" "production" {
+  account_id  = "8364ded2-5580-45c4-a394-edfa582e35a0"
+  name_prefix = "prod-"
+  region      = "us-east-1"
+  status      = "Ready"
+}
+
+output "psql_endpoints" {
+  description = "Endpoints of the production PGVecto.rs Cloud PostgreSQL databases"
+  value       = { for c in data.pgvecto-rs-cloud_clusters.production.clusters : c.cluster_name => c.connect_endpoint }
+}
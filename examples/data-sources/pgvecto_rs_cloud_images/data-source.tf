@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    pgvecto-rs-cloud = {
+      source = "tensorchord/pgvecto-rs-cloud"
+    }
+  }
+}
+
+provider "pgvecto-rs-cloud" {
+  api_key = "pgrs-xxxxxxxxxxxxxxxx"
+}
+
+data "pgvecto-rs-cloud_images" "pg16" {
+  pg_major_version = 16
+  extension        = "vectorchord"
+}
+
+resource "pgvecto-rs-cloud_cluster" "enterprise_plan_cluster" {
+  account_id        = "8364ded2-5580-45c4-a394-edfa582e35a0"
+  cluster_name      = "enterprise-plan-cluster"
+  plan              = "Enterprise"
+  server_resource   = "aws-m7i-large-2c-8g"
+  region            = "us-east-1"
+  cluster_provider  = "aws"
+  database_name     = "test"
+  pg_data_disk_size = "5"
+  image             = data.pgvecto-rs-cloud_images.pg16.latest
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    pgvecto-rs-cloud = {
+      source = "tensorchord/pgvecto-rs-cloud"
+    }
+  }
+}
+
+provider "pgvecto-rs-cloud" {
+  api_key = "pgrs-xxxxxxxxxxxxxxxx"
+}
+
+data "pgvecto-rs-cloud_plans" "all" {}
+
+locals {
+  # Pick a plan by capability rather than by name.
+  pitr_plan = [for p in data.pgvecto-rs-cloud_plans.all.plans : p if p.features.pitr][0]
+}
+
+resource "pgvecto-rs-cloud_cluster" "cluster" {
+  account_id        = "8364ded2-5580-45c4-a394-edfa582e35a0"
+  cluster_name      = "pitr-cluster"
+  plan              = local.pitr_plan.name
+  server_resource   = local.pitr_plan.server_resources[0]
+  region            = "us-east-1"
+  cluster_provider  = "aws"
+  database_name     = "test"
+  pg_data_disk_size = "10"
+  enable_pooler     = local.pitr_plan.features.pooler
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    pgvecto-rs-cloud = {
+      source = "tensorchord/pgvecto-rs-cloud"
+    }
+  }
+}
+
+provider "pgvecto-rs-cloud" {
+  api_key = "pgrs-xxxxxxxxxxxxxxxx"
+}
+
+data "pgvecto-rs-cloud_regions" "starter" {
+  plan = "Starter"
+}
+
+output "starter_regions" {
+  value = data.pgvecto-rs-cloud_regions.starter.regions[*].name
+}
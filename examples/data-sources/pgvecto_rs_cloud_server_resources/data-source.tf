@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    pgvecto-rs-cloud = {
+      source = "tensorchord/pgvecto-rs-cloud"
+    }
+  }
+}
+
+provider "pgvecto-rs-cloud" {
+  api_key = "pgrs-xxxxxxxxxxxxxxxx"
+}
+
+data "pgvecto-rs-cloud_server_resources" "enterprise" {
+  plan   = "Enterprise"
+  region = "us-east-1"
+}
+
+output "enterprise_server_resources" {
+  value = data.pgvecto-rs-cloud_server_resources.enterprise.server_resources[*].name
+}
@@ -0,0 +1,9 @@
+list "pgvecto-rs-cloud_cluster" "enterprise" {
+  provider = pgvecto-rs-cloud
+
+  config {
+    account_id = "8364ded2-5580-45c4-a394-edfa582e35a0"
+    region     = "us-east-1"
+    plan       = "Enterprise"
+  }
+}
@@ -0,0 +1,7 @@
+import {
+  to = pgvecto-rs-cloud_cluster.enterprise_plan_cluster
+  identity = {
+    account_id = "8364ded2-5580-45c4-a394-edfa582e35a0"
+    cluster_id = "7d3c88ec-8147-45b0-a79c-5568a9fd31db"
+  }
+}
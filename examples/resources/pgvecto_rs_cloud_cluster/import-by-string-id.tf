@@ -0,0 +1,4 @@
+import {
+  to = pgvecto-rs-cloud_cluster.enterprise_plan_cluster
+  id = "8364ded2-5580-45c4-a394-edfa582e35a0,7d3c88ec-8147-45b0-a79c-5568a9fd31db"
+}
@@ -0,0 +1,5 @@
+# Clusters can be imported by the account ID and the cluster ID, separated by a comma.
+terraform import pgvecto-rs-cloud_cluster.enterprise_plan_cluster 8364ded2-5580-45c4-a394-edfa582e35a0,7d3c88ec-8147-45b0-a79c-5568a9fd31db
+
+# When the provider sets account_id, the cluster ID alone is enough.
+terraform import pgvecto-rs-cloud_cluster.enterprise_plan_cluster 7d3c88ec-8147-45b0-a79c-5568a9fd31db
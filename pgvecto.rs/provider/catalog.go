@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// catalog caches the catalogs of the API for the lifetime of the provider
+// process, i.e. one Terraform command, so that plans validating many clusters
+// only fetch each of them once. Failures are cached too: an unreachable
+// catalog is not retried for every cluster. Fetches that fail because the
+// context of the caller was canceled or timed out are not cached, so that a
+// canceled plan of one cluster does not disable the checks of the others.
+type catalog struct {
+	images          cached[[]client.Image]
+	serverResources cached[[]client.ServerResourceInfo]
+	regions         cached[[]client.RegionInfo]
+	plans           cached[[]client.CNPGClusterPlanInfo]
+}
+
+func newCatalog(c *client.Client) *catalog {
+	return &catalog{
+		images:          cached[[]client.Image]{fetch: c.ListImages},
+		serverResources: cached[[]client.ServerResourceInfo]{fetch: c.ListServerResources},
+		regions:         cached[[]client.RegionInfo]{fetch: c.ListRegions},
+		plans:           cached[[]client.CNPGClusterPlanInfo]{fetch: c.ListPlans},
+	}
+}
+
+// Images returns the image catalog.
+func (c *catalog) Images(ctx context.Context) ([]client.Image, error) {
+	return c.images.get(ctx)
+}
+
+// ServerResources returns the instance type catalog.
+func (c *catalog) ServerResources(ctx context.Context) ([]client.ServerResourceInfo, error) {
+	return c.serverResources.get(ctx)
+}
+
+// Regions returns the region catalog.
+func (c *catalog) Regions(ctx context.Context) ([]client.RegionInfo, error) {
+	return c.regions.get(ctx)
+}
+
+// Plans returns the plan catalog.
+func (c *catalog) Plans(ctx context.Context) ([]client.CNPGClusterPlanInfo, error) {
+	return c.plans.get(ctx)
+}
+
+// cached fetches a value on first use and keeps it, or the error. The fetch
+// runs with the context of the caller, so a context.Canceled or
+// context.DeadlineExceeded error is returned to that caller only and the next
+// caller fetches again.
+type cached[T any] struct {
+	fetch func(context.Context) (T, error)
+
+	mu    sync.Mutex
+	done  bool
+	value T
+	err   error
+}
+
+func (c *cached[T]) get(ctx context.Context) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return c.value, c.err
+	}
+	value, err := c.fetch(ctx)
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return value, err
+	}
+	c.value, c.err, c.done = value, err, true
+	return c.value, c.err
+}
+
+// closestMatch returns the candidate with the smallest edit distance to s, to
+// suggest a fix for a typo. It returns false when there are no candidates.
+func closestMatch(s string, candidates []string) (string, bool) {
+	var best string
+	bestDistance := -1
+	for _, candidate := range candidates {
+		if d := editDistance(s, candidate); bestDistance < 0 || d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best, bestDistance >= 0
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCached_Get(t *testing.T) {
+	errUnavailable := errors.New("catalog unavailable")
+
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		{name: "value", wantCalls: 1},
+		{name: "error", err: errUnavailable, wantCalls: 1},
+		{name: "canceled", err: fmt.Errorf("list: %w", context.Canceled), wantCalls: 3},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, wantCalls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			c := cached[int]{fetch: func(ctx context.Context) (int, error) {
+				calls++
+				return 42, tt.err
+			}}
+			for i := 0; i < 3; i++ {
+				_, err := c.get(context.Background())
+				if !errors.Is(err, tt.err) {
+					t.Errorf("get() error = %v, want %v", err, tt.err)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("fetch calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	var calls int
+	c := cached[int]{fetch: func(ctx context.Context) (int, error) {
+		calls++
+		return calls, ctx.Err()
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.get(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("get() with a canceled context error = %v, want %v", err, context.Canceled)
+	}
+	if got, err := c.get(context.Background()); err != nil || got != 2 {
+		t.Errorf("get() after a canceled fetch = %d, %v, want 2, <nil>", got, err)
+	}
+}
@@ -3,11 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
@@ -15,6 +15,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ClusterDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ClusterDataSource{}
 
 func NewClusterDataSource() datasource.DataSource {
 	return &ClusterDataSource{}
@@ -32,6 +33,7 @@ type ClusterDataSourceModel struct {
 	ClusterName              types.String `tfsdk:"cluster_name"`
 	Plan                     types.String `tfsdk:"plan"`
 	Image                    types.String `tfsdk:"image"`
+	ImageFamily              types.String `tfsdk:"image_family"`
 	Region                   types.String `tfsdk:"region"`
 	ServerResource           types.String `tfsdk:"server_resource"`
 	ClusterProvider          types.String `tfsdk:"cluster_provider"`
@@ -54,88 +56,114 @@ func (d *ClusterDataSource) Metadata(ctx context.Context, req datasource.Metadat
 }
 
 func (r *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := clusterDataSourceAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "Cluster identifier. Exactly one of `id` or `cluster_name` must be set.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["cluster_name"] = schema.StringAttribute{
+		MarkdownDescription: "The name of the cluster to look up. Exactly one of `id` or `cluster_name` must be set.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["account_id"] = schema.StringAttribute{
+		MarkdownDescription: "Default Account Identifier for the PGVecto.rs cloud",
+		Required:            true,
+	}
+
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Cluster Data Source",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				MarkdownDescription: "Cluster identifier",
-				Required:            true,
-			},
-			"account_id": schema.StringAttribute{
-				MarkdownDescription: "Default Account Identifier for the PGVecto.rs cloud",
-				Required:            true,
-			},
-			"cluster_name": schema.StringAttribute{
-				MarkdownDescription: "The name of the cluster to be created. It is a string of no more than 32 characters.",
-				Computed:            true,
-			},
-			"plan": schema.StringAttribute{
-				MarkdownDescription: "The plan tier of the PGVecto.rs Cloud service. Available options are Starter and Enterprise.",
-				Computed:            true,
-			},
-			"image": schema.StringAttribute{
-				MarkdownDescription: "The image of the cluster instance.",
-				Computed:            true,
-			},
-			"server_resource": schema.StringAttribute{
-				MarkdownDescription: "The server resource of the cluster instance. Available aws-t3-xlarge-4c-16g, aws-m7i-large-2c-8g, aws-r7i-large-2c-16g,aws-r7i-xlarge-4c-32g",
-				Computed:            true,
-			},
-			"region": schema.StringAttribute{
-				MarkdownDescription: "The region of the cluster instance.Available options are us-east-1,eu-west-1",
-				Computed:            true,
-			},
-			"cluster_provider": schema.StringAttribute{
-				MarkdownDescription: "The cloud provider of the cluster instance. At present, only aws is supported.",
-				Computed:            true,
-			},
-			"status": schema.StringAttribute{
-				MarkdownDescription: "The current status of the cluster. Possible values are Initializing, Ready, NotReady, Deleted, Upgrading, Suspended, Resuming.",
-				Computed:            true,
-			},
-			"connect_endpoint": schema.StringAttribute{
-				MarkdownDescription: "The psql connection endpoint of the cluster.",
-				Computed:            true,
-			},
-			"pg_data_disk_size": schema.StringAttribute{
-				MarkdownDescription: "The size of the PGData disk in GB, please insert between 1 and 16384.",
-				Computed:            true,
-			},
-			"last_updated": schema.StringAttribute{
-				Computed: true,
-			},
-			"database_name": schema.StringAttribute{
-				MarkdownDescription: "The name of the database.",
-				Computed:            true,
-			},
-			"enable_pooler": schema.BoolAttribute{
-				MarkdownDescription: "Enable connection pooler.",
-				Computed:            true,
-			},
-			"enable_restore": schema.BoolAttribute{
-				MarkdownDescription: "Enable restore.",
-				Computed:            true,
-			},
-			"target_cluster_id": schema.StringAttribute{
-				MarkdownDescription: "The target cluster ID for restore.",
-				Computed:            true,
-			},
-			"backup_id": schema.StringAttribute{
-				MarkdownDescription: "The backup ID for restore.",
-				Computed:            true,
-			},
-			"target_time": schema.StringAttribute{
-				MarkdownDescription: "The target time for restore.",
-				Computed:            true,
-			},
-			"first_recoverability_point": schema.StringAttribute{
-				MarkdownDescription: "The first recoverability point.",
-				Computed:            true,
-			},
-			"last_archived_wal_time": schema.StringAttribute{
-				MarkdownDescription: "The last archived WAL time.",
-				Computed:            true,
-			},
+		Attributes:          attributes,
+	}
+}
+
+// clusterDataSourceAttributes returns the attributes describing a cluster,
+// all computed. It is shared by the cluster and clusters data sources.
+func clusterDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Cluster identifier",
+			Computed:            true,
+		},
+		"account_id": schema.StringAttribute{
+			MarkdownDescription: "Default Account Identifier for the PGVecto.rs cloud",
+			Computed:            true,
+		},
+		"cluster_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the cluster.",
+			Computed:            true,
+		},
+		"plan": schema.StringAttribute{
+			MarkdownDescription: "The plan tier of the PGVecto.rs Cloud service. Available options are Starter and Enterprise.",
+			Computed:            true,
+		},
+		"image": schema.StringAttribute{
+			MarkdownDescription: "The image of the cluster instance. Images of the default repositories are a bare tag, other images a full reference.",
+			Computed:            true,
+		},
+		"image_family": schema.StringAttribute{
+			MarkdownDescription: "The vector extension shipped in the image, pgvecto.rs or vectorchord. Null when it cannot be guessed from the image.",
+			Computed:            true,
+		},
+		"server_resource": schema.StringAttribute{
+			MarkdownDescription: "The server resource of the cluster instance, e.g. aws-m7i-large-2c-8g.",
+			Computed:            true,
+		},
+		"region": schema.StringAttribute{
+			MarkdownDescription: "The region of the cluster instance, e.g. us-east-1.",
+			Computed:            true,
+		},
+		"cluster_provider": schema.StringAttribute{
+			MarkdownDescription: "The cloud provider of the cluster instance. At present, only aws is supported.",
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			MarkdownDescription: "The current status of the cluster. Possible values are Initializing, Ready, NotReady, Deleted, Upgrading, Suspended, Resuming.",
+			Computed:            true,
+		},
+		"connect_endpoint": schema.StringAttribute{
+			MarkdownDescription: "The psql connection endpoint of the cluster.",
+			Computed:            true,
+		},
+		"pg_data_disk_size": schema.StringAttribute{
+			MarkdownDescription: "The size of the PGData disk in GB.",
+			Computed:            true,
+		},
+		"last_updated": schema.StringAttribute{
+			Computed: true,
+		},
+		"database_name": schema.StringAttribute{
+			MarkdownDescription: "The name of the database.",
+			Computed:            true,
+		},
+		"enable_pooler": schema.BoolAttribute{
+			MarkdownDescription: "Enable connection pooler.",
+			Computed:            true,
+		},
+		"enable_restore": schema.BoolAttribute{
+			MarkdownDescription: "Enable restore.",
+			Computed:            true,
+		},
+		"target_cluster_id": schema.StringAttribute{
+			MarkdownDescription: "The target cluster ID for restore.",
+			Computed:            true,
+		},
+		"backup_id": schema.StringAttribute{
+			MarkdownDescription: "The backup ID for restore.",
+			Computed:            true,
+		},
+		"target_time": schema.StringAttribute{
+			MarkdownDescription: "The target time for restore.",
+			Computed:            true,
+		},
+		"first_recoverability_point": schema.StringAttribute{
+			MarkdownDescription: "The first recoverability point.",
+			Computed:            true,
+		},
+		"last_archived_wal_time": schema.StringAttribute{
+			MarkdownDescription: "The last archived WAL time.",
+			Computed:            true,
 		},
 	}
 }
@@ -146,18 +174,39 @@ func (d *ClusterDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = data.client
+}
+
+func (d *ClusterDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config ClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The lookup key cannot be checked until it is known.
+	if config.ClusterId.IsUnknown() || config.ClusterName.IsUnknown() {
+		return
+	}
+
+	if config.ClusterId.IsNull() == config.ClusterName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Attribute Combination",
+			"Exactly one of `id` or `cluster_name` must be set.",
+		)
+	}
 }
 
 func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -171,26 +220,43 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	tflog.Trace(ctx, "sending describe project request...")
-	if state.ClusterId.IsNull() {
-		resp.Diagnostics.AddError("Invalid Cluster ID", "Cluster ID is required")
-		return
-	}
-
 	if state.AccountId.IsNull() {
 		resp.Diagnostics.AddError("Invalid Account ID", "Account ID is required")
 		return
 	}
-	c, err := d.client.GetCluster(state.AccountId.ValueString(), state.ClusterId.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to GetCluster %s, got error: %v", state.ClusterId.ValueString(), err))
-		return
+
+	var c *client.CNPGCluster
+	var err error
+	if !state.ClusterId.IsNull() {
+		c, err = d.client.GetCluster(ctx, state.AccountId.ValueString(), state.ClusterId.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to read cluster %s", state.ClusterId.ValueString()), err)
+			return
+		}
+	} else {
+		c, err = d.client.FindClusterByName(ctx, state.AccountId.ValueString(), state.ClusterName.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Unable to find cluster named %q", state.ClusterName.ValueString()), err)
+			return
+		}
 	}
 
 	// Save data into Terraform state
+	state.setCluster(c)
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// setCluster copies the cluster returned by the API into the model.
+func (state *ClusterDataSourceModel) setCluster(c *client.CNPGCluster) {
 	state.ClusterId = types.StringValue(c.Spec.ID)
 	state.ClusterName = types.StringValue(c.Spec.Name)
 	state.Plan = types.StringValue(string(c.Spec.Plan))
-	state.Image = types.StringValue(strings.Split(c.Spec.PostgreSQLConfig.Image, ":")[1])
+	state.Image, state.ImageFamily = imageFromAPI(c.Spec.PostgreSQLConfig.Image, types.StringNull(), types.StringNull())
 	state.ServerResource = types.StringValue(string(c.Spec.ServerResource))
 	state.Region = types.StringValue(c.Spec.ClusterProvider.Region)
 	state.ClusterProvider = types.StringValue(string(c.Spec.ClusterProvider.Type))
@@ -223,10 +289,4 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 	state.FirstRecoverabilityPoint = types.StringValue(c.Status.FirstRecoverabilityPoint.Format(time.RFC3339))
 	state.LastArchivedWALTime = types.StringValue(c.Status.LastArchivedWALTime.Format(time.RFC3339))
-
-	diags := resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 }
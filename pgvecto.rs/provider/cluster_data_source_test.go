@@ -1,5 +1,14 @@
 package provider
 
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
 /*
 func TestAccClusterDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -26,3 +35,95 @@ data "pgvecto-rs-cloud_cluster" "starter_plan_cluster" {
 `
 }
 */
+
+func TestClusterDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+
+	existing := p.api.AddCluster(testAccountID, client.CNPGCluster{
+		Spec: client.CNPGClusterSpec{
+			Name:            "console",
+			Plan:            client.CNPGClusterPlanEnterprise,
+			ServerResource:  client.ServerResourceAWSM7ILarge,
+			ClusterProvider: client.ClusterProvider{Type: client.AWSCloudProvider, Region: "us-east-1"},
+			PostgreSQLConfig: client.PostgreSQLConfig{
+				Image:          "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts",
+				PGDataDiskSize: "5",
+				EnablePooler:   true,
+				VectorConfig:   client.VectorConfig{DatabaseName: "test"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name  string
+		attrs map[string]tftypes.Value
+	}{
+		{name: "id", attrs: map[string]tftypes.Value{
+			"account_id": tftypes.NewValue(tftypes.String, testAccountID),
+			"id":         tftypes.NewValue(tftypes.String, existing.Spec.ID),
+		}},
+		{name: "cluster_name", attrs: map[string]tftypes.Value{
+			"account_id":   tftypes.NewValue(tftypes.String, testAccountID),
+			"cluster_name": tftypes.NewValue(tftypes.String, "console"),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := p.readDataSource("pgvecto-rs-cloud_cluster", tt.attrs)
+			requireNoErrors(t, "read", diags)
+			for name, want := range map[string]string{
+				"id":                existing.Spec.ID,
+				"cluster_name":      "console",
+				"plan":              "Enterprise",
+				"image":             "16-v0.4.0-extensions-exts",
+				"server_resource":   "aws-m7i-large-2c-8g",
+				"pg_data_disk_size": "5",
+				"enable_pooler":     "true",
+				"connect_endpoint":  existing.Status.Endpoint.PoolerUserEndpoint,
+			} {
+				if got := stateAttr(t, state, name); got != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+
+	_, diags := p.readDataSource("pgvecto-rs-cloud_cluster", map[string]tftypes.Value{
+		"account_id":   tftypes.NewValue(tftypes.String, testAccountID),
+		"cluster_name": tftypes.NewValue(tftypes.String, "missing"),
+	})
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, `Unable to find cluster named "missing"`)
+	if !strings.Contains(d.Detail, "does not exist") {
+		t.Errorf("not found detail = %q, want the not found hint", d.Detail)
+	}
+
+	duplicate := p.api.AddCluster(testAccountID, client.CNPGCluster{
+		Spec: client.CNPGClusterSpec{
+			Name:            "console",
+			Plan:            client.CNPGClusterPlanStarter,
+			ServerResource:  client.ServerResourceAWST3XLarge,
+			ClusterProvider: client.ClusterProvider{Type: client.AWSCloudProvider, Region: "us-east-1"},
+			PostgreSQLConfig: client.PostgreSQLConfig{
+				Image:          "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts",
+				PGDataDiskSize: "5",
+				VectorConfig:   client.VectorConfig{DatabaseName: "test"},
+			},
+		},
+	})
+	_, diags = p.readDataSource("pgvecto-rs-cloud_cluster", map[string]tftypes.Value{
+		"account_id":   tftypes.NewValue(tftypes.String, testAccountID),
+		"cluster_name": tftypes.NewValue(tftypes.String, "console"),
+	})
+	d = requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, `Unable to find cluster named "console"`)
+	for _, id := range []string{existing.Spec.ID, duplicate.Spec.ID} {
+		if !strings.Contains(d.Detail, id) {
+			t.Errorf("duplicate name detail = %q, want it to list %s", d.Detail, id)
+		}
+	}
+
+	_, diags = p.readDataSource("pgvecto-rs-cloud_cluster", map[string]tftypes.Value{
+		"account_id": tftypes.NewValue(tftypes.String, testAccountID),
+		"id":         tftypes.NewValue(tftypes.String, "missing"),
+	})
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to read cluster missing")
+}
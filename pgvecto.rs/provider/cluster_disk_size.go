@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	minDiskSize = 1
+	maxDiskSize = 16384
+)
+
+// diskSizePattern matches disk sizes such as 10, 10Gi or 1Ti. Sizes without a
+// unit are in GB, like the sizes accepted by the API. Only the binary units
+// are accepted: 1TB would be 1000 GB, which the API cannot represent as a
+// whole number of its 1024-based gigabytes.
+var diskSizePattern = regexp.MustCompile(`^\s*(\d+)\s*(|Gi|Ti)\s*$`)
+
+// parseDiskSize returns a disk size in GB.
+func parseDiskSize(s string) (int, error) {
+	m := diskSizePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid disk size %q, expected a number of GB such as 10, or a size in Gi or Ti such as 10Gi or 1Ti", s)
+	}
+	size, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid disk size %q: %w", s, err)
+	}
+	if m[2] == "Ti" {
+		size *= 1024
+	}
+	return size, nil
+}
+
+var _ basetypes.StringTypable = diskSizeType{}
+
+// diskSizeType is the type of pg_data_disk_size. Its values accept a unit and
+// compare by size, so that 10 and the 10Gi returned by the API do not show up
+// as a change.
+type diskSizeType struct {
+	basetypes.StringType
+}
+
+func (t diskSizeType) String() string {
+	return "diskSizeType"
+}
+
+func (t diskSizeType) Equal(o attr.Type) bool {
+	other, ok := o.(diskSizeType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t diskSizeType) ValueType(ctx context.Context) attr.Value {
+	return diskSizeValue{}
+}
+
+func (t diskSizeType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return diskSizeValue{StringValue: in}, nil
+}
+
+func (t diskSizeType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return diskSizeValue{StringValue: stringValue}, nil
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = diskSizeValue{}
+
+// diskSizeValue is a disk size in GB, with an optional unit.
+type diskSizeValue struct {
+	basetypes.StringValue
+}
+
+func newDiskSizeValue(s string) diskSizeValue {
+	return diskSizeValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+// diskSizeFromAPI returns the disk size returned by the API in GB without a
+// unit. Other sizes such as 5G are read as their number, and a missing size
+// is null, so that unexpected values do not fail the read.
+func diskSizeFromAPI(s string) diskSizeValue {
+	if size, err := parseDiskSize(s); err == nil {
+		return newDiskSizeValue(strconv.Itoa(size))
+	}
+	normalized := strings.TrimFunc(s, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if normalized == "" {
+		return diskSizeValue{StringValue: basetypes.NewStringNull()}
+	}
+	return newDiskSizeValue(normalized)
+}
+
+func (v diskSizeValue) Type(ctx context.Context) attr.Type {
+	return diskSizeType{}
+}
+
+func (v diskSizeValue) Equal(o attr.Value) bool {
+	other, ok := o.(diskSizeValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// Size returns the disk size in GB, and false when it is not set, not known
+// yet or invalid.
+func (v diskSizeValue) Size() (int, bool) {
+	if v.IsNull() || v.IsUnknown() {
+		return 0, false
+	}
+	size, err := parseDiskSize(v.ValueString())
+	return size, err == nil
+}
+
+// APIValue returns the disk size sent to the API, in GB without a unit.
+func (v diskSizeValue) APIValue() string {
+	if size, ok := v.Size(); ok {
+		return strconv.Itoa(size)
+	}
+	return v.ValueString()
+}
+
+func (v diskSizeValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(diskSizeValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	size, ok := v.Size()
+	if !ok {
+		return false, diags
+	}
+	newSize, ok := newValue.Size()
+	return ok && size == newSize, diags
+}
+
+// diskSizeValidator checks configured disk sizes. Sizes returned by the API
+// are not checked, so that they cannot break the state.
+type diskSizeValidator struct{}
+
+func (v diskSizeValidator) Description(ctx context.Context) string {
+	return "Validate disk size"
+}
+
+func (v diskSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validate disk size"
+}
+
+func (v diskSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	size, err := parseDiskSize(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid disk size", err.Error())
+		return
+	}
+	if size < minDiskSize || size > maxDiskSize {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid disk size",
+			fmt.Sprintf("The PGData disk size must be between %d and %d GB, got %d GB.", minDiskSize, maxDiskSize, size))
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseDiskSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "10", want: 10},
+		{in: "10Gi", want: 10},
+		{in: " 10 Gi ", want: 10},
+		{in: "1Ti", want: 1024},
+		{in: "10G", wantErr: true},
+		{in: "10GB", wantErr: true},
+		{in: "10GiB", wantErr: true},
+		{in: "1T", wantErr: true},
+		{in: "1TB", wantErr: true},
+		{in: "10Mi", wantErr: true},
+		{in: "ten", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDiskSize(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseDiskSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseDiskSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDiskSizeValue_StringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "10", b: "10Gi", want: true},
+		{a: "1Ti", b: "1024", want: true},
+		{a: "10", b: "20Gi"},
+		{a: "ten", b: "ten"},
+	}
+	for _, tt := range tests {
+		got, diags := newDiskSizeValue(tt.a).StringSemanticEquals(context.Background(), newDiskSizeValue(tt.b))
+		if diags.HasError() {
+			t.Fatalf("StringSemanticEquals(%q, %q) diagnostics = %v", tt.a, tt.b, diags)
+		}
+		if got != tt.want {
+			t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// isImageTag reports whether the image attribute holds a bare tag, such as
+// 16-v0.4.0-extensions-exts, rather than a full image reference.
+func isImageTag(image string) bool {
+	return !strings.ContainsAny(image, "/:@")
+}
+
+// resolveImage returns the image reference sent to the API for the image and
+// image_family attributes. A bare tag refers to the default repository of the
+// family, which is guessed from the tag when image_family is not set.
+func resolveImage(image, family types.String) (client.ImageReference, client.ImageExtension, error) {
+	extension := client.ImageExtension(family.ValueString())
+
+	if isImageTag(image.ValueString()) {
+		if extension == "" {
+			// Only pgvecto.rs tags carry the exts suffix.
+			extension = client.ImageExtensionVectorChord
+			if strings.Contains(image.ValueString(), "exts") {
+				extension = client.ImageExtensionPGVectoRS
+			}
+		}
+		ref := client.ImageReference{
+			Repository: extension.DefaultRepository(),
+			Tag:        image.ValueString(),
+		}
+		if ref.Repository == "" {
+			return client.ImageReference{}, "", fmt.Errorf("unknown image family %q", extension)
+		}
+		return ref, extension, nil
+	}
+
+	ref, err := client.ParseImageReference(image.ValueString())
+	if err != nil {
+		return client.ImageReference{}, "", err
+	}
+	if extension == "" {
+		extension = ref.Extension()
+	}
+	if extension == "" {
+		return client.ImageReference{}, "", fmt.Errorf("unable to guess the extension of image %s, set image_family", ref)
+	}
+	return ref, extension, nil
+}
+
+// defaultRepositoryFamily returns the family guessed from ref, and whether
+// ref is in the default repository of that family. The family of such images
+// is always taken from their repository.
+func defaultRepositoryFamily(ref client.ImageReference) (client.ImageExtension, bool) {
+	family := ref.Extension()
+	return family, family != "" && ref.Registry == "" && ref.Repository == family.DefaultRepository()
+}
+
+// imageFromAPI maps the image returned by the API to the image and
+// image_family attributes. The prior values are kept when they refer to the
+// same image, so that a tag or a full reference round-trips as it was
+// configured. Otherwise images of the default repositories are stored as bare
+// tags and other images as full references.
+func imageFromAPI(apiImage string, priorImage, priorFamily types.String) (types.String, types.String) {
+	ref, err := client.ParseImageReference(apiImage)
+	if err != nil {
+		// Keep unexpected values as they are rather than failing the read.
+		return types.StringValue(apiImage), priorFamily
+	}
+
+	family, fromRepository := defaultRepositoryFamily(ref)
+	if !fromRepository {
+		// The repository does not tell the family of mirrored or custom
+		// images, so a family set in the configuration wins over the guess.
+		if !priorFamily.IsNull() && !priorFamily.IsUnknown() {
+			family = client.ImageExtension(priorFamily.ValueString())
+		}
+	}
+	familyValue := types.StringNull()
+	if family != "" {
+		familyValue = types.StringValue(string(family))
+	}
+
+	if !priorImage.IsNull() && !priorImage.IsUnknown() {
+		if prior, _, err := resolveImage(priorImage, familyValue); err == nil && prior.String() == ref.String() {
+			return priorImage, familyValue
+		}
+	}
+
+	if ref.Registry == "" && ref.Digest == "" && ref.Tag != "" && ref.Repository == family.DefaultRepository() {
+		return types.StringValue(ref.Tag), familyValue
+	}
+	return types.StringValue(ref.String()), familyValue
+}
+
+type imageFamilyValidator struct{}
+
+func (v imageFamilyValidator) Description(ctx context.Context) string {
+	return "Validate image family"
+}
+
+func (v imageFamilyValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validate image family"
+}
+
+func (v imageFamilyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	switch client.ImageExtension(req.ConfigValue.ValueString()) {
+	case client.ImageExtensionPGVectoRS, client.ImageExtensionVectorChord:
+	default:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid image family",
+			fmt.Sprintf("Invalid image family: %s. Available options are %s and %s.", req.ConfigValue.ValueString(), client.ImageExtensionPGVectoRS, client.ImageExtensionVectorChord))
+	}
+}
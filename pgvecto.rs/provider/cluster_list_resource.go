@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ClusterListResource{}
+var _ list.ListResourceWithConfigure = &ClusterListResource{}
+
+func NewClusterListResource() list.ListResource {
+	return &ClusterListResource{}
+}
+
+// ClusterListResource lists the clusters of an account so that they can be
+// discovered with terraform query and imported.
+type ClusterListResource struct {
+	client           *client.Client
+	defaultAccountID string
+}
+
+// ClusterListResourceModel describes the list resource configuration.
+type ClusterListResourceModel struct {
+	AccountId types.String `tfsdk:"account_id"`
+	Region    types.String `tfsdk:"region"`
+	Plan      types.String `tfsdk:"plan"`
+}
+
+func (r *ClusterListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (r *ClusterListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the clusters of an account, optionally filtered by region or plan.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Account Identifier for the PGVecto.rs cloud. Defaults to the provider account_id.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters in this region, e.g. us-east-1.",
+				Optional:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters on this plan. Available options are Starter and Enterprise.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ClusterListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = data.client
+	r.defaultAccountID = data.defaultAccountID
+}
+
+func (r *ClusterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config ClusterListResourceModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	accountID := config.AccountId.ValueString()
+	if accountID == "" {
+		accountID = r.defaultAccountID
+	}
+	if accountID == "" {
+		diags.AddError(
+			"Missing Account Identifier",
+			"Set account_id in the list block or the account_id provider setting.",
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tflog.Trace(ctx, "sending list clusters request...")
+	it := r.client.NewClusterIterator(accountID, client.ListClustersOptions{
+		Region: config.Region.ValueString(),
+		Plan:   client.CNPGClusterPlan(config.Plan.ValueString()),
+	})
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for it.Next(ctx) {
+			c := it.Cluster()
+			if c.Status.Status == client.CNPGClusterStatusDeleted {
+				continue
+			}
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+			count++
+
+			if !push(r.listResult(ctx, req, accountID, &c)) {
+				return
+			}
+		}
+
+		if err := it.Err(); err != nil {
+			var diags diag.Diagnostics
+			addClientError(&diags, "Unable to list clusters", err)
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
+
+func (r *ClusterListResource) listResult(ctx context.Context, req list.ListRequest, accountID string, c *client.CNPGCluster) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = c.Spec.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, ClusterIdentityModel{
+		AccountId: types.StringValue(accountID),
+		ClusterId: types.StringValue(c.Spec.ID),
+	})...)
+
+	if req.IncludeResource {
+		data := ClusterResourceModel{
+			AccountId: types.StringValue(accountID),
+			Timeouts: timeouts.Value{
+				Object: types.ObjectNull(map[string]attr.Type{
+					"create": types.StringType,
+					"update": types.StringType,
+					"delete": types.StringType,
+				}),
+			},
+		}
+		data.setCluster(c)
+		result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+	}
+
+	return result
+}
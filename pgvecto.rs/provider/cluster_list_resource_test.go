@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+func TestClusterListResource_List(t *testing.T) {
+	p := newTestProvider(t)
+
+	prod := addTestCluster(p, testAccountID, "app-prod", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusReady)
+	addTestCluster(p, testAccountID, "app-dev", client.CNPGClusterPlanStarter, "eu-west-1", client.CNPGClusterStatusReady)
+	addTestCluster(p, testAccountID, "app-old", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusDeleted)
+	addTestCluster(p, testAccountID, "search", client.CNPGClusterPlanEnterprise, "eu-west-1", client.CNPGClusterStatusSuspended)
+	addTestCluster(p, "another-account", "app-other", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusReady)
+
+	tests := []struct {
+		name      string
+		attrs     map[string]tftypes.Value
+		limit     int64
+		wantNames string
+	}{
+		{
+			name:      "provider account",
+			wantNames: "app-prod,app-dev,search",
+		},
+		{
+			name: "account",
+			attrs: map[string]tftypes.Value{
+				"account_id": tftypes.NewValue(tftypes.String, "another-account"),
+			},
+			wantNames: "app-other",
+		},
+		{
+			name: "region",
+			attrs: map[string]tftypes.Value{
+				"region": tftypes.NewValue(tftypes.String, "eu-west-1"),
+			},
+			wantNames: "app-dev,search",
+		},
+		{
+			name: "plan",
+			attrs: map[string]tftypes.Value{
+				"plan": tftypes.NewValue(tftypes.String, "Enterprise"),
+			},
+			wantNames: "app-prod,search",
+		},
+		{
+			name:      "limit",
+			limit:     2,
+			wantNames: "app-prod,app-dev",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, diags := p.list(testClusterType, tt.attrs, false, tt.limit)
+			requireNoErrors(t, "list", diags)
+
+			var names []string
+			for _, result := range results {
+				names = append(names, result.DisplayName)
+				if result.Resource != nil {
+					t.Errorf("result %s has a resource without include_resource", result.DisplayName)
+				}
+			}
+			if got := strings.Join(names, ","); got != tt.wantNames {
+				t.Errorf("clusters = %q, want %q", got, tt.wantNames)
+			}
+		})
+	}
+
+	results, diags := p.list(testClusterType, map[string]tftypes.Value{
+		"region": tftypes.NewValue(tftypes.String, "us-east-1"),
+	}, true, 0)
+	requireNoErrors(t, "list", diags)
+	if len(results) != 1 {
+		t.Fatalf("clusters = %d, want 1", len(results))
+	}
+	identity := testIdentity(t, p, &testResource{typeName: testClusterType, identity: results[0].Identity})
+	for name, want := range map[string]string{
+		"account_id": testAccountID,
+		"cluster_id": prod.Spec.ID,
+	} {
+		if got := stateAttr(t, identity, name); got != want {
+			t.Errorf("identity %s = %q, want %q", name, got, want)
+		}
+	}
+	if results[0].Resource == nil {
+		t.Fatalf("result has no resource with include_resource")
+	}
+	state := p.value(p.resourceSchema(testClusterType).ValueType(), results[0].Resource)
+	for name, want := range map[string]string{
+		"id":                prod.Spec.ID,
+		"account_id":        testAccountID,
+		"cluster_name":      "app-prod",
+		"plan":              "Enterprise",
+		"image":             "16-v0.4.0-extensions-exts",
+		"server_resource":   "aws-m7i-large-2c-8g",
+		"region":            "us-east-1",
+		"pg_data_disk_size": "5",
+		"status":            "Ready",
+	} {
+		if got := stateAttr(t, state, name); got != want {
+			t.Errorf("resource %s = %q, want %q", name, got, want)
+		}
+	}
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpList, Status: 500, Code: 50000, Message: "internal error"})
+	results, diags = p.list(testClusterType, nil, false, 0)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list clusters")
+	if len(results) != 0 {
+		t.Errorf("failed list returned %d clusters", len(results))
+	}
+}
@@ -12,10 +12,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier" // Import the tfsdk package
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -25,12 +28,15 @@ import (
 const (
 	defaultClusterCreateTimeout time.Duration = 5 * time.Minute
 	defaultClusterUpdateTimeout time.Duration = 5 * time.Minute
+	defaultClusterDeleteTimeout time.Duration = 5 * time.Minute
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterResource{}
 var _ resource.ResourceWithConfigure = &ClusterResource{}
 var _ resource.ResourceWithImportState = &ClusterResource{}
+var _ resource.ResourceWithIdentity = &ClusterResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterResource{}
 
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
@@ -38,7 +44,9 @@ func NewClusterResource() resource.Resource {
 
 // ClusterResource defines the resource implementation.
 type ClusterResource struct {
-	client *client.Client
+	client           *client.Client
+	defaultAccountID string
+	catalog          *catalog
 }
 
 func (r *ClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,16 +69,19 @@ func (v imageValidator) ValidateString(ctx context.Context, req validator.String
 		return
 	}
 
-	var tag types.String
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("image"), &tag)...)
-	if resp.Diagnostics.HasError() {
+	image := req.ConfigValue.ValueString()
+	if !isImageTag(image) {
+		if _, err := client.ParseImageReference(image); err != nil {
+			resp.Diagnostics.AddError("Invalid image reference", err.Error())
+		}
 		return
 	}
+
 	pattern := `^\d+-v\d+\.\d+\.\d+(?:-[a-zA-Z]+(?:-[a-zA-Z]+)?)?$`
 	re := regexp.MustCompile(pattern)
-	match := re.MatchString(tag.ValueString())
+	match := re.MatchString(image)
 	if !match {
-		resp.Diagnostics.AddError("Invalid image tag", fmt.Sprintf("Invalid image tag: %s", tag.ValueString()))
+		resp.Diagnostics.AddError("Invalid image tag", fmt.Sprintf("Invalid image tag: %s", image))
 	}
 }
 
@@ -100,22 +111,32 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				},
 			},
 			"plan": schema.StringAttribute{
-				MarkdownDescription: "The plan tier of the PGVecto.rs Cloud service. Available options are Starter and Enterprise.",
+				MarkdownDescription: "The plan tier of the PGVecto.rs Cloud service. Available options are Starter and Enterprise. Use the `pgvecto-rs-cloud_plans` data source to list the capabilities of the plans; the configuration is checked against them when planning.",
 				Required:            true,
 			},
 			"server_resource": schema.StringAttribute{
-				MarkdownDescription: "The server resource of the cluster instance. Available aws-t3-xlarge-4c-16g, aws-m7i-large-2c-8g, aws-r7i-large-2c-16g,aws-r7i-xlarge-4c-32g,aws-i4i-xlarge-4c-32g",
+				MarkdownDescription: "The server resource of the cluster instance, e.g. aws-m7i-large-2c-8g. Use the `pgvecto-rs-cloud_server_resources` data source to list the instance types available with a plan and in a region. It is checked against the catalog when planning.",
 				Required:            true,
 			},
 			"image": schema.StringAttribute{
-				MarkdownDescription: "The image of the cluster instance. You can specify the tag of the image, please select limited tags in https://cloud.pgvecto.rs/api/v1/images",
-				Required:            true,
+				MarkdownDescription: "The image of the cluster instance. You can specify the tag of the image, please select limited tags in https://cloud.pgvecto.rs/api/v1/images or with the `pgvecto-rs-cloud_images` data source. " +
+					"New tags are checked against the image catalog when planning. A full image reference such as `registry.example.com:5000/modelzai/vchord-cnpg:17-v0.2.0@sha256:...` is accepted too, e.g. to use a mirror or pin a digest.",
+				Required: true,
 				Validators: []validator.String{
 					imageValidator{},
 				},
 			},
+			"image_family": schema.StringAttribute{
+				MarkdownDescription: "The vector extension shipped in the image. Available options are pgvecto.rs and vectorchord. " +
+					"A tag given as `image` refers to the modelzai/pgvecto-rs or modelzai/vchord-cnpg repository of the family. Guessed from the image when not set, and must match the repository of images of those repositories. Changing the family of an existing cluster replaces it.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					imageFamilyValidator{},
+				},
+			},
 			"region": schema.StringAttribute{
-				MarkdownDescription: "The region of the cluster instance.Available options are us-east-1,eu-west-1",
+				MarkdownDescription: "The region of the cluster instance, e.g. us-east-1. Use the `pgvecto-rs-cloud_regions` data source to list the regions available with a plan. It is checked against the catalog when planning.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -129,13 +150,26 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "The current status of the cluster. Possible values are Initializing, Ready, NotReady, Deleted, Upgrading, Suspended, Resuming.",
 				Computed:            true,
 			},
+			"suspended": schema.BoolAttribute{
+				MarkdownDescription: "Whether the cluster is suspended. Suspended clusters keep their data but run no instances, e.g. to park development clusters overnight. " +
+					"Changing it suspends or resumes the cluster. When not set, the suspension is not managed and follows the status of the cluster.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"connect_endpoint": schema.StringAttribute{
 				MarkdownDescription: "The psql connection endpoint of the cluster.",
 				Computed:            true,
 			},
 			"pg_data_disk_size": schema.StringAttribute{
-				MarkdownDescription: "The size of the PGData disk in GB, please insert between 1 and 16384.",
+				MarkdownDescription: "The size of the PGData disk in GB, please insert between 1 and 16384. A size in Gi or Ti such as `10Gi` or `1Ti` is accepted too. The disk can grow but not shrink.",
+				CustomType:          diskSizeType{},
 				Optional:            true,
+				Validators: []validator.String{
+					diskSizeValidator{},
+				},
 			},
 			"database_name": schema.StringAttribute{
 				MarkdownDescription: "The name of the database.",
@@ -155,6 +189,11 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Enable restore from backup or target cluster(PITR)",
 				Optional:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Adopt a cluster with the same `cluster_name` that already exists in the account instead of creating a new one. " +
+					"This also recovers clusters created by a request whose response was lost. Its plan, server resource, region and database name must match the configuration.",
+				Optional: true,
+			},
 			"backup_id": schema.StringAttribute{
 				MarkdownDescription: "The backup id to restore from",
 				Optional:            true,
@@ -187,6 +226,10 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 					UpdateDescription: `Timeout defaults to 5 mins. Accepts a string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) ` +
 						`consisting of numbers and unit suffixes, such as "30s" or "2h45m". Valid time units are ` +
 						`"s" (seconds), "m" (minutes), "h" (hours).`,
+					Delete: true,
+					DeleteDescription: `Timeout defaults to 5 mins. Accepts a string that can be [parsed as a duration](https://pkg.go.dev/time#ParseDuration) ` +
+						`consisting of numbers and unit suffixes, such as "30s" or "2h45m". Valid time units are ` +
+						`"s" (seconds), "m" (minutes), "h" (hours).`,
 				},
 			),
 		},
@@ -198,71 +241,378 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = data.client
+	r.defaultAccountID = data.defaultAccountID
+	r.catalog = data.catalog
 }
 
-func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	tflog.Info(ctx, "Create Cluster...")
-	var data ClusterResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the cluster is destroyed or the provider is not
+	// configured yet, e.g. during terraform validate.
+	if req.Plan.Raw.IsNull() || r.catalog == nil {
+		return
+	}
+
+	var plan ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	checkPlan := func(data ClusterResourceModel) (bool, error) {
-		if data.Plan.IsNull() {
-			return false, errors.New("plan is required")
+	var state *ClusterResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &ClusterResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
+	}
 
-		switch client.CNPGClusterPlan(data.Plan.ValueString()) {
-		case client.CNPGClusterPlanStarter, client.CNPGClusterPlanEnterprise:
-			return true, nil
-		default:
-			return false, fmt.Errorf("invalid plan: %s", data.Plan.ValueString())
-		}
+	r.modifyImagePlan(ctx, plan, state, resp)
+
+	// Like images, instance types, regions and plan capabilities are only
+	// checked when they change, so that catalog changes do not block
+	// existing clusters.
+	if state == nil || !state.ServerResource.Equal(plan.ServerResource) ||
+		!state.Region.Equal(plan.Region) || !state.Plan.Equal(plan.Plan) {
+		resp.Diagnostics.Append(r.checkServerResource(ctx, plan)...)
+		resp.Diagnostics.Append(r.checkRegion(ctx, plan)...)
 	}
+	if state == nil || !state.Plan.Equal(plan.Plan) || !state.ServerResource.Equal(plan.ServerResource) ||
+		!state.EnablePooler.Equal(plan.EnablePooler) || !state.EnableRestore.Equal(plan.EnableRestore) ||
+		!state.TargetTime.Equal(plan.TargetTime) || !state.PGDataDiskSize.Equal(plan.PGDataDiskSize) {
+		resp.Diagnostics.Append(r.checkPlan(ctx, plan, state)...)
+	}
+	resp.Diagnostics.Append(checkDiskShrink(plan, state)...)
 
-	if _, err := checkPlan(data); err != nil {
-		resp.Diagnostics.AddError("check plan", err.Error())
+	if state != nil && state.Suspended.ValueBool() && plan.Suspended.ValueBool() && upgradeRequested(plan, *state) {
+		resp.Diagnostics.AddAttributeError(path.Root("suspended"), "Cluster is suspended",
+			fmt.Sprintf("Cluster %s is suspended and cannot be upgraded. Set suspended to false to resume it during the upgrade, then suspend it again.", state.ClusterId.ValueString()))
+	}
+}
+
+// modifyImagePlan computes image_family when it is not set, replaces clusters
+// whose family changes and checks new images against the image catalog.
+func (r *ClusterResource) modifyImagePlan(ctx context.Context, plan ClusterResourceModel, state *ClusterResourceModel, resp *resource.ModifyPlanResponse) {
+	if plan.Image.IsUnknown() || plan.Image.IsNull() {
 		return
 	}
 
-	checkServerResource := func(data ClusterResourceModel) (bool, error) {
-		if data.ServerResource.IsNull() {
-			return false, fmt.Errorf("ServerResource is required")
+	ref, family, err := resolveImage(plan.Image, plan.ImageFamily)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("image"), "Invalid image", err.Error())
+		return
+	}
+	if plan.ImageFamily.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_family"), string(family))...)
+	}
+	if repositoryFamily, ok := defaultRepositoryFamily(ref); ok && repositoryFamily != family {
+		resp.Diagnostics.AddAttributeError(path.Root("image_family"), "Conflicting image family",
+			fmt.Sprintf("Image %s ships %s, not %s. Set image_family to %s or remove it.", ref, repositoryFamily, family, repositoryFamily))
+		return
+	}
+	// Upgrades keep the extension of the cluster, so another family needs a
+	// new cluster.
+	if state != nil && !state.ImageFamily.IsNull() && state.ImageFamily.ValueString() != string(family) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("image_family"))
+	}
+
+	// Existing clusters keep working when their image is removed from the
+	// catalog, so the image is only checked when it changes. Mirrored and
+	// custom images are not in the catalog.
+	if (state == nil || !state.Image.Equal(plan.Image)) &&
+		ref.Registry == "" && ref.Tag != "" && ref.Repository == family.DefaultRepository() {
+		resp.Diagnostics.Append(r.checkImage(ctx, ref.Tag)...)
+	}
+}
+
+// checkImage checks that tag is in the image catalog and suggests the closest
+// tag when it is not. When the catalog cannot be fetched, only the format
+// checked by imageValidator is enforced.
+func (r *ClusterResource) checkImage(ctx context.Context, tag string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	images, err := r.catalog.Images(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the image catalog, only checking the format of the image tag", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+
+	var tags []string
+	for _, image := range images {
+		if image.Tag == tag {
+			if image.Deprecated {
+				diags.AddAttributeWarning(path.Root("image"), "Deprecated image",
+					fmt.Sprintf("Image %s is deprecated and will be removed. Use the pgvecto-rs-cloud_images data source to select a supported image.", tag))
+			}
+			return diags
 		}
+		if !image.Deprecated {
+			tags = append(tags, image.Tag)
+		}
+	}
 
-		switch client.ServerResource(data.ServerResource.ValueString()) {
-		case client.ServerResourceAWST3XLarge, client.ServerResourceAWSM7ILarge, client.ServerResourceAWSR7ILarge, client.ServerResourceAWSR7IXLarge:
-			return true, nil
-		default:
-			return false, fmt.Errorf("invalid ServerResource: %s", data.ServerResource.ValueString())
+	detail := fmt.Sprintf("Image %s is not available in PGVecto.rs Cloud.", tag)
+	if closest, ok := closestMatch(tag, tags); ok {
+		detail += fmt.Sprintf(" Did you mean %s?", closest)
+	}
+	detail += " Use the pgvecto-rs-cloud_images data source to list the available images."
+	diags.AddAttributeError(path.Root("image"), "Unknown image", detail)
+	return diags
+}
+
+// checkServerResource checks that the server resource is in the instance type
+// catalog and available in the region of the cluster. Whether it is available
+// with the plan is checked by checkPlan. When the catalog cannot be fetched,
+// the check is left to the API.
+func (r *ClusterResource) checkServerResource(ctx context.Context, plan ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.ServerResource.IsUnknown() || plan.ServerResource.IsNull() {
+		return diags
+	}
+
+	resources, err := r.catalog.ServerResources(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the server resource catalog, skipping the server resource check", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+
+	name := plan.ServerResource.ValueString()
+	var names []string
+	for _, info := range resources {
+		if string(info.Name) != name {
+			names = append(names, string(info.Name))
+			continue
 		}
+
+		if !plan.Region.IsUnknown() && !plan.Region.IsNull() && !info.AvailableIn(plan.Region.ValueString()) {
+			diags.AddAttributeError(path.Root("server_resource"), "Server resource not available in region",
+				fmt.Sprintf("Server resource %s is not available in %s, only in %s. Use the pgvecto-rs-cloud_server_resources data source to list the instance types of a region.",
+					name, plan.Region.ValueString(), strings.Join(info.Regions, ", ")))
+		}
+		return diags
 	}
 
-	if _, err := checkServerResource(data); err != nil {
-		resp.Diagnostics.AddError("check ServerResource", err.Error())
+	detail := fmt.Sprintf("Server resource %s is not available in PGVecto.rs Cloud.", name)
+	if closest, ok := closestMatch(name, names); ok {
+		detail += fmt.Sprintf(" Did you mean %s?", closest)
+	}
+	detail += " Use the pgvecto-rs-cloud_server_resources data source to list the available instance types."
+	diags.AddAttributeError(path.Root("server_resource"), "Unknown server resource", detail)
+	return diags
+}
+
+// checkRegion checks that the region is in the region catalog and available
+// with the plan of the cluster. When the catalog cannot be fetched, the check
+// is left to the API.
+func (r *ClusterResource) checkRegion(ctx context.Context, plan ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Region.IsUnknown() || plan.Region.IsNull() {
+		return diags
+	}
+
+	regions, err := r.catalog.Regions(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the region catalog, skipping the region check", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+
+	name := plan.Region.ValueString()
+	var names []string
+	for _, region := range regions {
+		if region.Name != name {
+			names = append(names, region.Name)
+			continue
+		}
+
+		if !plan.Plan.IsUnknown() && !plan.Plan.IsNull() && !region.AvailableWith(client.CNPGClusterPlan(plan.Plan.ValueString())) {
+			diags.AddAttributeError(path.Root("region"), "Region not available with plan",
+				fmt.Sprintf("Region %s is not available with the %s plan, only with %s. Use the pgvecto-rs-cloud_regions data source to list the regions of a plan.",
+					name, plan.Plan.ValueString(), joinPlans(region.Plans)))
+		}
+		return diags
+	}
+
+	detail := fmt.Sprintf("Region %s is not available in PGVecto.rs Cloud.", name)
+	if closest, ok := closestMatch(name, names); ok {
+		detail += fmt.Sprintf(" Did you mean %s?", closest)
+	}
+	detail += " Use the pgvecto-rs-cloud_regions data source to list the available regions."
+	diags.AddAttributeError(path.Root("region"), "Unknown region", detail)
+	return diags
+}
+
+// checkPlan checks that the plan is in the plan catalog and that the
+// configuration only uses capabilities of the plan: its server resources,
+// disk size limits, the connection pooler and point-in-time recovery. When
+// the catalog cannot be fetched, the checks are left to the API.
+func (r *ClusterResource) checkPlan(ctx context.Context, plan ClusterResourceModel, state *ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.Plan.IsUnknown() || plan.Plan.IsNull() {
+		return diags
+	}
+
+	plans, err := r.catalog.Plans(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to fetch the plan catalog, skipping the plan capability checks", map[string]interface{}{"error": err.Error()})
+		return diags
+	}
+
+	name := client.CNPGClusterPlan(plan.Plan.ValueString())
+	info, ok := findPlan(plans, name)
+	if !ok {
+		names := make([]string, 0, len(plans))
+		for _, p := range plans {
+			names = append(names, string(p.Plan))
+		}
+		detail := fmt.Sprintf("Plan %s is not available in PGVecto.rs Cloud.", name)
+		if closest, ok := closestMatch(string(name), names); ok {
+			detail += fmt.Sprintf(" Did you mean %s?", closest)
+		}
+		detail += " Use the pgvecto-rs-cloud_plans data source to list the available plans."
+		diags.AddAttributeError(path.Root("plan"), "Unknown plan", detail)
+		return diags
+	}
+
+	// Plans that do not list their server resources leave the check to the
+	// API.
+	if !plan.ServerResource.IsUnknown() && !plan.ServerResource.IsNull() && len(info.ServerResources) > 0 &&
+		!info.AllowsServerResource(client.ServerResource(plan.ServerResource.ValueString())) {
+		diags.AddAttributeError(path.Root("server_resource"), "Server resource not available with plan",
+			fmt.Sprintf("The %s plan runs %s clusters on %s, not on %s. Use the pgvecto-rs-cloud_plans data source to list the server resources of a plan.",
+				name, strings.ToLower(string(info.Type)), joinServerResources(info.ServerResources), plan.ServerResource.ValueString()))
+	}
+
+	if plan.EnablePooler.ValueBool() && !info.Features.Pooler {
+		diags.AddAttributeError(path.Root("enable_pooler"), "Pooler not available with plan",
+			fmt.Sprintf("The %s plan does not support the connection pooler. Set enable_pooler to false or use a plan with the pooler feature.", name))
+	}
+
+	if plan.EnableRestore.ValueBool() && !plan.TargetTime.IsNull() && !info.Features.PITR {
+		diags.AddAttributeError(path.Root("target_time"), "Point-in-time recovery not available with plan",
+			fmt.Sprintf("The %s plan does not support point-in-time recovery. Restore from a backup_id instead or use a plan with the pitr feature.", name))
+	}
+
+	if size, ok := plan.PGDataDiskSize.Size(); ok {
+		if limit, ok := planDiskSizeLimit(info, size); !ok {
+			diags.AddAttributeError(path.Root("pg_data_disk_size"), "Disk size not available with plan",
+				fmt.Sprintf("The PGData disk of %s plan clusters must be %s, got %d GB.", name, limit, size))
+		}
+	}
+
+	if state != nil && !state.Plan.Equal(plan.Plan) {
+		if prior, ok := findPlan(plans, client.CNPGClusterPlan(state.Plan.ValueString())); ok &&
+			prior.Features.HighAvailability && !info.Features.HighAvailability {
+			diags.AddAttributeWarning(path.Root("plan"), "Plan without high availability",
+				fmt.Sprintf("The %s plan does not run standby instances: the cluster will be unavailable during failures and maintenance once it moves from the %s plan.", name, prior.Plan))
+		}
+	}
+	return diags
+}
+
+// checkDiskShrink rejects plans shrinking the PGData disk, which the API only
+// rejects while applying.
+func checkDiskShrink(plan ClusterResourceModel, state *ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if state == nil {
+		return diags
+	}
+
+	prior, ok := state.PGDataDiskSize.Size()
+	if !ok {
+		return diags
+	}
+	if size, ok := plan.PGDataDiskSize.Size(); ok && size < prior {
+		diags.AddAttributeError(path.Root("pg_data_disk_size"), "Disk shrink not supported",
+			fmt.Sprintf("The PGData disk cannot be shrunk from %d to %d GB. Keep the current size or create a new cluster with a smaller disk.", prior, size))
+	}
+	return diags
+}
+
+// planDiskSizeLimit reports whether size is within the disk size bounds of
+// the plan, and describes the bounds that are set when it is not.
+func planDiskSizeLimit(info client.CNPGClusterPlanInfo, size int) (string, bool) {
+	tooSmall := info.MinDiskSize > 0 && size < info.MinDiskSize
+	tooLarge := info.MaxDiskSize > 0 && size > info.MaxDiskSize
+	switch {
+	case !tooSmall && !tooLarge:
+		return "", true
+	case info.MinDiskSize > 0 && info.MaxDiskSize > 0:
+		return fmt.Sprintf("between %d and %d GB", info.MinDiskSize, info.MaxDiskSize), false
+	case tooSmall:
+		return fmt.Sprintf("at least %d GB", info.MinDiskSize), false
+	default:
+		return fmt.Sprintf("at most %d GB", info.MaxDiskSize), false
+	}
+}
+
+func findPlan(plans []client.CNPGClusterPlanInfo, name client.CNPGClusterPlan) (client.CNPGClusterPlanInfo, bool) {
+	for _, p := range plans {
+		if p.Plan == name {
+			return p, true
+		}
+	}
+	return client.CNPGClusterPlanInfo{}, false
+}
+
+func joinServerResources(resources []client.ServerResource) string {
+	names := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		names = append(names, string(resource))
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinPlans(plans []client.CNPGClusterPlan) string {
+	names := make([]string, 0, len(plans))
+	for _, plan := range plans {
+		names = append(names, string(plan))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Create Cluster...")
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create() is passed a default timeout to use if no value
+	// has been supplied in the Terraform configuration. The timeout
+	// bounds every API call made below, not only the readiness wait.
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultClusterCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Clusters are created running and suspended once they are ready.
+	suspend := data.Suspended.ValueBool()
+
 	var response *client.CNPGCluster
-	var err error
 
-	image := fmt.Sprintf("modelzai/pgvecto-rs:%s", data.Image.ValueString())
-	if !strings.Contains(image, "exts") {
-		image = fmt.Sprintf("modelzai/vchord-cnpg:%s", data.Image.ValueString())
+	image, family, err := resolveImage(data.Image, data.ImageFamily)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("image"), "Invalid image", err.Error())
+		return
 	}
+	data.ImageFamily = types.StringValue(string(family))
 
 	spec := client.CNPGClusterSpec{
 		Name:           data.ClusterName.ValueString(),
@@ -273,8 +623,8 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 			Region: data.Region.ValueString(),
 		},
 		PostgreSQLConfig: client.PostgreSQLConfig{
-			Image:          image,
-			PGDataDiskSize: data.PGDataDiskSize.ValueString(),
+			Image:          image.String(),
+			PGDataDiskSize: data.PGDataDiskSize.APIValue(),
 			VectorConfig: client.VectorConfig{
 				DatabaseName: data.DatabaseName.ValueString(),
 			},
@@ -314,73 +664,67 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		}
 
 	}
-	response, err = r.client.CreateCluster(spec, data.AccountId.ValueString())
-
-	if err != nil {
-		err := client.Error{}
-		if errors.As(err, &client.Error{}) {
-			resp.Diagnostics.AddError("Failed to create cluster", err.Message)
+	if data.AdoptExisting.ValueBool() {
+		response, err = r.client.FindClusterByName(ctx, data.AccountId.ValueString(), data.ClusterName.ValueString())
+		switch {
+		case err == nil:
+			tflog.Info(ctx, "Adopting existing cluster", map[string]interface{}{"id": response.Spec.ID})
+		case errors.Is(err, client.ErrNotFound):
+			response = nil
+		default:
+			addClientError(&resp.Diagnostics, "Failed to look up existing cluster", err)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to create cluster", err.Error())
-		return
-	}
-
-	data.ClusterId = types.StringValue(response.Spec.ID)
-	data.ClusterName = types.StringValue(response.Spec.Name)
-	data.Plan = types.StringValue(string(response.Spec.Plan))
-	data.Image = types.StringValue(strings.Split(response.Spec.PostgreSQLConfig.Image, ":")[1])
-	data.ServerResource = types.StringValue(string(response.Spec.ServerResource))
-	data.Region = types.StringValue(response.Spec.ClusterProvider.Region)
-	data.ClusterProvider = types.StringValue(string(response.Spec.ClusterProvider.Type))
-	data.Status = types.StringValue(string(response.Status.Status))
-	data.ConnectEndpoint = types.StringValue(response.Status.Endpoint.VectorUserEndpoint)
-	if response.Status.Endpoint.PoolerUserEndpoint != "" {
-		data.ConnectEndpoint = types.StringValue(response.Status.Endpoint.PoolerUserEndpoint)
-	}
-	normalized := strings.TrimFunc(response.Spec.PostgreSQLConfig.PGDataDiskSize, func(r rune) bool {
-		return r < '0' || r > '9'
-	})
-	data.PGDataDiskSize = types.StringValue(normalized)
-	data.DatabaseName = types.StringValue(response.Spec.PostgreSQLConfig.VectorConfig.DatabaseName)
-	data.LastUpdated = types.StringValue(response.Status.UpdatedAt.Format(time.RFC3339))
-	if response.Spec.PostgreSQLConfig.EnablePooler {
-		data.EnablePooler = types.BoolValue(response.Spec.PostgreSQLConfig.EnablePooler)
 	}
 
-	if response.Spec.PostgreSQLConfig.RestoreConfig.Enabled {
-		data.EnableRestore = types.BoolValue(response.Spec.PostgreSQLConfig.RestoreConfig.Enabled)
-	}
-
-	if response.Spec.PostgreSQLConfig.RestoreConfig.ClusterID != "" {
-		data.TargetClusterID = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.ClusterID)
-	}
+	if response == nil {
+		response, err = r.client.CreateCluster(ctx, spec, data.AccountId.ValueString())
+		if err != nil && data.AdoptExisting.ValueBool() {
+			// The request may have failed after the cluster was created,
+			// e.g. when the connection dropped before the response arrived.
+			if existing, findErr := r.client.FindClusterByName(ctx, data.AccountId.ValueString(), data.ClusterName.ValueString()); findErr == nil {
+				tflog.Warn(ctx, "Create request failed but the cluster exists, adopting it", map[string]interface{}{"id": existing.Spec.ID, "error": err.Error()})
+				response, err = existing, nil
+			}
+		}
 
-	if response.Spec.PostgreSQLConfig.RestoreConfig.BackupID != "" {
-		data.BackupID = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.BackupID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Failed to create cluster", err)
+			return
+		}
+	} else if mismatch := adoptionMismatch(data, response); mismatch != "" {
+		resp.Diagnostics.AddError(
+			"Existing cluster does not match the configuration",
+			fmt.Sprintf("Cluster %s named %q already exists but its %s. Update the configuration to match it, or rename the cluster.",
+				response.Spec.ID, response.Spec.Name, mismatch),
+		)
+		return
 	}
 
-	if !response.Spec.PostgreSQLConfig.RestoreConfig.TargetTime.IsZero() {
-		data.TargetTime = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.TargetTime.Format(time.RFC3339))
-	}
-	data.FirstRecoverabilityPoint = types.StringValue(response.Status.FirstRecoverabilityPoint.Format(time.RFC3339))
-	data.LastArchivedWALTime = types.StringValue(response.Status.LastArchivedWALTime.Format(time.RFC3339))
+	data.setCluster(response)
 
-	// Wait for cluster to be RUNNING
-	// Create() is passed a default timeout to use if no value
-	// has been supplied in the Terraform configuration.
-	createTimeout, diags := data.Timeouts.Create(ctx, defaultClusterCreateTimeout)
-	resp.Diagnostics.Append(diags...)
+	// Save the cluster before waiting for it, so that a failed or timed out
+	// wait leaves a tainted resource in state instead of an orphaned cluster.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(data.setIdentity(ctx, resp.Identity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Wait for cluster to be RUNNING
 	resp.Diagnostics.Append(data.waitForStatus(ctx, createTimeout, r.client, string(client.CNPGClusterStatusReady))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	resp.Diagnostics.Append(data.refresh(r.client)...)
+	if suspend {
+		resp.Diagnostics.Append(data.setSuspended(ctx, createTimeout, r.client, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.refresh(ctx, r.client)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -392,8 +736,54 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	resp.Diagnostics.Append(state.refresh(r.client)...)
+	c, err := r.client.GetCluster(ctx, state.AccountId.ValueString(), state.ClusterId.ValueString())
+	if isClusterGone(c, err) {
+		resp.Diagnostics.AddWarning(
+			"Cluster no longer exists",
+			fmt.Sprintf("Cluster %s in account %s was deleted outside of Terraform and has been removed from the state. "+
+				"Terraform will plan to create it again.", state.ClusterId.ValueString(), state.AccountId.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to read cluster", err)
+		return
+	}
+
+	state.setCluster(c)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(state.setIdentity(ctx, resp.Identity)...)
+}
+
+// adoptionMismatch describes the first setting of an existing cluster that
+// differs from the planned one, or returns an empty string if they match.
+func adoptionMismatch(data ClusterResourceModel, c *client.CNPGCluster) string {
+	checks := []struct {
+		name    string
+		planned string
+		actual  string
+	}{
+		{"plan", data.Plan.ValueString(), string(c.Spec.Plan)},
+		{"server_resource", data.ServerResource.ValueString(), string(c.Spec.ServerResource)},
+		{"region", data.Region.ValueString(), c.Spec.ClusterProvider.Region},
+		{"database_name", data.DatabaseName.ValueString(), c.Spec.PostgreSQLConfig.VectorConfig.DatabaseName},
+	}
+	for _, check := range checks {
+		if check.planned != check.actual {
+			return fmt.Sprintf("%s is %q instead of %q", check.name, check.actual, check.planned)
+		}
+	}
+	return ""
+}
+
+// isClusterGone reports whether a GetCluster result means that the cluster
+// does not exist anymore.
+func isClusterGone(c *client.CNPGCluster, err error) bool {
+	if err != nil {
+		return errors.Is(err, client.ErrNotFound)
+	}
+	return c.Status.Status == client.CNPGClusterStatusDeleted
 }
 
 func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -414,75 +804,66 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	// Only support changes of plan, server_resource, pg_data_disk_size
-	response, err := r.client.UpgradeCluster(state.AccountId.String(), state.ClusterId.ValueString(), client.CNPGClusterUpgradeRequest{
-		Plan:           client.CNPGClusterPlan(plan.Plan.ValueString()),
-		ServerResource: client.ServerResource(plan.ServerResource.ValueString()),
-		PGDataDiskSize: plan.PGDataDiskSize.ValueString(),
-	})
-
-	state.ClusterId = types.StringValue(response.Spec.ID)
-	state.ClusterName = types.StringValue(response.Spec.Name)
-	state.Plan = types.StringValue(string(response.Spec.Plan))
-	state.Image = types.StringValue(strings.Split(response.Spec.PostgreSQLConfig.Image, ":")[1])
-	state.ServerResource = types.StringValue(string(response.Spec.ServerResource))
-	state.Region = types.StringValue(response.Spec.ClusterProvider.Region)
-	state.ClusterProvider = types.StringValue(string(response.Spec.ClusterProvider.Type))
-	state.Status = types.StringValue(string(response.Status.Status))
-	state.ConnectEndpoint = types.StringValue(response.Status.Endpoint.VectorUserEndpoint)
-	if response.Status.Endpoint.PoolerUserEndpoint != "" {
-		state.ConnectEndpoint = types.StringValue(response.Status.Endpoint.PoolerUserEndpoint)
-	}
-	normalized := strings.TrimFunc(response.Spec.PostgreSQLConfig.PGDataDiskSize, func(r rune) bool {
-		return r < '0' || r > '9'
-	})
-	state.PGDataDiskSize = types.StringValue(normalized)
-	state.DatabaseName = types.StringValue(response.Spec.PostgreSQLConfig.VectorConfig.DatabaseName)
-	state.LastUpdated = types.StringValue(response.Status.UpdatedAt.Format(time.RFC3339))
-	if response.Spec.PostgreSQLConfig.EnablePooler {
-		state.EnablePooler = types.BoolValue(response.Spec.PostgreSQLConfig.EnablePooler)
+	// Update() is passed a default timeout to use if no value
+	// has been supplied in the Terraform configuration.
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultClusterUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if response.Spec.PostgreSQLConfig.RestoreConfig.Enabled {
-		state.EnableRestore = types.BoolValue(response.Spec.PostgreSQLConfig.RestoreConfig.Enabled)
-	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
-	if response.Spec.PostgreSQLConfig.RestoreConfig.ClusterID != "" {
-		state.TargetClusterID = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.ClusterID)
-	}
+	// A cluster is resumed before it is upgraded and suspended after, so that
+	// both can change in one apply. Upgrading a cluster that stays suspended
+	// is rejected when planning.
+	suspend := plan.Suspended.ValueBool() && !state.Suspended.ValueBool()
+	resume := state.Suspended.ValueBool() && !plan.Suspended.IsUnknown() && !plan.Suspended.ValueBool()
 
-	if response.Spec.PostgreSQLConfig.RestoreConfig.BackupID != "" {
-		state.BackupID = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.BackupID)
+	if resume {
+		resp.Diagnostics.Append(state.setSuspended(ctx, updateTimeout, r.client, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	if !response.Spec.PostgreSQLConfig.RestoreConfig.TargetTime.IsZero() {
-		state.TargetTime = types.StringValue(response.Spec.PostgreSQLConfig.RestoreConfig.TargetTime.Format(time.RFC3339))
-	}
-	state.FirstRecoverabilityPoint = types.StringValue(response.Status.FirstRecoverabilityPoint.Format(time.RFC3339))
-	state.LastArchivedWALTime = types.StringValue(response.Status.LastArchivedWALTime.Format(time.RFC3339))
+	if upgradeRequested(plan, state) {
+		// Only support changes of plan, server_resource, pg_data_disk_size
+		response, err := r.client.UpgradeCluster(ctx, state.AccountId.ValueString(), state.ClusterId.ValueString(), client.CNPGClusterUpgradeRequest{
+			Plan:           client.CNPGClusterPlan(plan.Plan.ValueString()),
+			ServerResource: client.ServerResource(plan.ServerResource.ValueString()),
+			PGDataDiskSize: plan.PGDataDiskSize.APIValue(),
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Failed to upgrade cluster", err)
+			return
+		}
+		state.setCluster(response)
 
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to upgrade cluster", err.Error())
-		return
+		// Wait for cluster to be RUNNING
+		resp.Diagnostics.Append(state.waitForStatus(ctx, updateTimeout, r.client, string(client.CNPGClusterStatusReady))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	// Wait for cluster to be RUNNING
-	// Update() is passed a default timeout to use if no value
-	// has been supplied in the Terraform configuration.
-	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultClusterUpdateTimeout)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
+	if suspend {
+		resp.Diagnostics.Append(state.setSuspended(ctx, updateTimeout, r.client, true)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	resp.Diagnostics.Append(state.waitForStatus(ctx, updateTimeout, r.client, string(client.CNPGClusterStatusReady))...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	// adopt_existing and timeouts only live in the configuration, so they
+	// are taken from the plan rather than from the prior state.
+	state.AdoptExisting = plan.AdoptExisting
+	state.Timeouts = plan.Timeouts
 
 	// Save updated data into Terraform state
-	resp.Diagnostics.Append(state.refresh(r.client)...)
+	resp.Diagnostics.Append(state.refresh(ctx, r.client)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(state.setIdentity(ctx, resp.Identity)...)
 }
 
 func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -493,25 +874,96 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteCluster(data.AccountId.String(), data.ClusterId.ValueString())
+	// Delete() is passed a default timeout to use if no value
+	// has been supplied in the Terraform configuration.
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultClusterDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete cluster", err.Error())
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		addClientError(&resp.Diagnostics, "Failed to delete cluster", err)
 		return
 	}
+
+	// Wait for cluster to be gone
+	resp.Diagnostics.Append(data.waitForDeletion(ctx, deleteTimeout, r.client)...)
+}
+
+func (r *ClusterResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"account_id": identityschema.StringAttribute{
+				Description:       "Account Identifier for the PGVecto.rs cloud",
+				RequiredForImport: true,
+			},
+			"cluster_id": identityschema.StringAttribute{
+				Description:       "Cluster identifier",
+				RequiredForImport: true,
+			},
+		},
+	}
 }
 
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ",")
+	var accountID, clusterID string
+	if req.ID != "" {
+		var err error
+		accountID, clusterID, err = parseClusterImportID(req.ID, r.defaultAccountID)
+		if err != nil {
+			resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+			return
+		}
+	} else {
+		// Imported with an identity attribute in an import block (Terraform 1.12+).
+		var identity ClusterIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		accountID = identity.AccountId.ValueString()
+		clusterID = identity.ClusterId.ValueString()
+	}
 
-	if len(idParts) != 1 || idParts[0] == "" {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: clusterId. Got: %q", req.ID),
-		)
-		return
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("account_id"), accountID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterID)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, ClusterIdentityModel{
+			AccountId: types.StringValue(accountID),
+			ClusterId: types.StringValue(clusterID),
+		})...)
 	}
+}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), idParts[0])...)
+// parseClusterImportID splits an import identifier of the form
+// accountId,clusterId or accountId/clusterId. A bare clusterId is accepted
+// when the provider has a default account.
+func parseClusterImportID(id string, defaultAccountID string) (string, string, error) {
+	sep := ","
+	if !strings.Contains(id, sep) {
+		sep = "/"
+	}
+	idParts := strings.Split(id, sep)
+
+	switch {
+	case len(idParts) == 2 && idParts[0] != "" && idParts[1] != "":
+		return idParts[0], idParts[1], nil
+	case len(idParts) == 1 && idParts[0] != "" && defaultAccountID != "":
+		return defaultAccountID, idParts[0], nil
+	case len(idParts) == 1 && idParts[0] != "":
+		return "", "", fmt.Errorf("Expected import identifier with format: accountId,clusterId. Got: %q. "+
+			"Importing by clusterId alone requires the account_id provider setting.", id)
+	default:
+		return "", "", fmt.Errorf("Expected import identifier with format: accountId,clusterId. Got: %q", id)
+	}
 }
 
 // ClusterResourceModel describes the resource data model.
@@ -523,15 +975,18 @@ type ClusterResourceModel struct {
 	Region                   types.String   `tfsdk:"region"`
 	ServerResource           types.String   `tfsdk:"server_resource"`
 	Image                    types.String   `tfsdk:"image"`
+	ImageFamily              types.String   `tfsdk:"image_family"`
 	ClusterProvider          types.String   `tfsdk:"cluster_provider"`
 	Status                   types.String   `tfsdk:"status"`
+	Suspended                types.Bool     `tfsdk:"suspended"`
 	ConnectEndpoint          types.String   `tfsdk:"connect_endpoint"`
-	PGDataDiskSize           types.String   `tfsdk:"pg_data_disk_size"`
+	PGDataDiskSize           diskSizeValue  `tfsdk:"pg_data_disk_size"`
 	DatabaseName             types.String   `tfsdk:"database_name"`
 	LastUpdated              types.String   `tfsdk:"last_updated"`
 	Timeouts                 timeouts.Value `tfsdk:"timeouts"`
 	EnablePooler             types.Bool     `tfsdk:"enable_pooler"`
 	EnableRestore            types.Bool     `tfsdk:"enable_restore"`
+	AdoptExisting            types.Bool     `tfsdk:"adopt_existing"`
 	BackupID                 types.String   `tfsdk:"backup_id"`
 	TargetClusterID          types.String   `tfsdk:"target_cluster_id"`
 	TargetTime               types.String   `tfsdk:"target_time"`
@@ -539,30 +994,54 @@ type ClusterResourceModel struct {
 	LastArchivedWALTime      types.String   `tfsdk:"last_archived_wal_time"`
 }
 
-func (data *ClusterResourceModel) refresh(client *client.Client) diag.Diagnostics {
+// ClusterIdentityModel describes the resource identity data model.
+type ClusterIdentityModel struct {
+	AccountId types.String `tfsdk:"account_id"`
+	ClusterId types.String `tfsdk:"cluster_id"`
+}
+
+// setIdentity stores the identity of the cluster. identity is nil when
+// Terraform does not support resource identities.
+func (data *ClusterResourceModel) setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, ClusterIdentityModel{
+		AccountId: data.AccountId,
+		ClusterId: data.ClusterId,
+	})
+}
+
+func (data *ClusterResourceModel) refresh(ctx context.Context, client *client.Client) diag.Diagnostics {
 	var diags diag.Diagnostics
 	var err error
 
-	c, err := client.GetCluster(data.AccountId.String(), data.ClusterId.ValueString())
+	c, err := client.GetCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString())
 	if err != nil {
-		diags.AddError("Client Error", fmt.Sprintf("Unable to GetCluster, got error: %s", err))
+		addClientError(&diags, "Unable to read cluster", err)
 		return diags
 	}
 
-	// Save data into Terraform state
+	data.setCluster(c)
+	return diags
+}
+
+// setCluster copies the cluster returned by the API into the model.
+func (data *ClusterResourceModel) setCluster(c *client.CNPGCluster) {
 	data.ClusterId = types.StringValue(c.Spec.ID)
 	data.ClusterName = types.StringValue(c.Spec.Name)
 	data.Plan = types.StringValue(string(c.Spec.Plan))
-	data.Image = types.StringValue(strings.Split(c.Spec.PostgreSQLConfig.Image, ":")[1])
+	data.Image, data.ImageFamily = imageFromAPI(c.Spec.PostgreSQLConfig.Image, data.Image, data.ImageFamily)
 	data.ServerResource = types.StringValue(string(c.Spec.ServerResource))
 	data.Region = types.StringValue(c.Spec.ClusterProvider.Region)
 	data.ClusterProvider = types.StringValue(string(c.Spec.ClusterProvider.Type))
 	data.Status = types.StringValue(string(c.Status.Status))
+	data.Suspended = types.BoolValue(c.Status.Status == client.CNPGClusterStatusSuspended)
 	data.ConnectEndpoint = types.StringValue(c.Status.Endpoint.VectorUserEndpoint)
 	if c.Status.Endpoint.PoolerUserEndpoint != "" {
 		data.ConnectEndpoint = types.StringValue(c.Status.Endpoint.PoolerUserEndpoint)
 	}
-	data.PGDataDiskSize = types.StringValue(c.Spec.PostgreSQLConfig.PGDataDiskSize)
+	data.PGDataDiskSize = diskSizeFromAPI(c.Spec.PostgreSQLConfig.PGDataDiskSize)
 	data.DatabaseName = types.StringValue(c.Spec.PostgreSQLConfig.VectorConfig.DatabaseName)
 	data.LastUpdated = types.StringValue(c.Status.UpdatedAt.Format(time.RFC3339))
 	if c.Spec.PostgreSQLConfig.EnablePooler {
@@ -586,26 +1065,75 @@ func (data *ClusterResourceModel) refresh(client *client.Client) diag.Diagnostic
 	}
 	data.FirstRecoverabilityPoint = types.StringValue(c.Status.FirstRecoverabilityPoint.Format(time.RFC3339))
 	data.LastArchivedWALTime = types.StringValue(c.Status.LastArchivedWALTime.Format(time.RFC3339))
-	return diags
 }
 
 func (data *ClusterResourceModel) waitForStatus(ctx context.Context, timeout time.Duration, client *client.Client, status string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
-		cluster, err := client.GetCluster(data.AccountId.String(), data.ClusterId.ValueString())
+		cluster, err := client.GetCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString())
 		if err != nil {
 			return retry.NonRetryableError(err)
 		}
 
 		if string(cluster.Status.Status) != status {
-			return retry.RetryableError(fmt.Errorf("cluster not yet in the %s state. Current state: %s", status, cluster.Status))
+			return retry.RetryableError(fmt.Errorf("cluster not yet in the %s state. Current state: %s", status, cluster.Status.Status))
 		}
 		return nil
 	})
 
 	if err != nil {
-		diags.AddError("Failed to wait for cluster to enter the Ready state.", err.Error())
+		addClientError(&diags, fmt.Sprintf("Failed to wait for cluster to enter the %s state.", status), err)
+	}
+
+	return diags
+}
+
+// setSuspended suspends or resumes the cluster and waits for it to be
+// Suspended or Ready.
+func (data *ClusterResourceModel) setSuspended(ctx context.Context, timeout time.Duration, c *client.Client, suspended bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if suspended {
+		if _, err := c.SuspendCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString()); err != nil {
+			addClientError(&diags, "Failed to suspend cluster", err)
+			return diags
+		}
+		return data.waitForStatus(ctx, timeout, c, string(client.CNPGClusterStatusSuspended))
+	}
+
+	if _, err := c.ResumeCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString()); err != nil {
+		addClientError(&diags, "Failed to resume cluster", err)
+		return diags
+	}
+	return data.waitForStatus(ctx, timeout, c, string(client.CNPGClusterStatusReady))
+}
+
+// upgradeRequested reports whether the plan changes a setting applied by
+// UpgradeCluster.
+func upgradeRequested(plan, state ClusterResourceModel) bool {
+	planSize, _ := plan.PGDataDiskSize.Size()
+	stateSize, _ := state.PGDataDiskSize.Size()
+	return !plan.Plan.Equal(state.Plan) || !plan.ServerResource.Equal(state.ServerResource) || planSize != stateSize
+}
+
+func (data *ClusterResourceModel) waitForDeletion(ctx context.Context, timeout time.Duration, client *client.Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		cluster, err := client.GetCluster(ctx, data.AccountId.ValueString(), data.ClusterId.ValueString())
+		if isClusterGone(cluster, err) {
+			return nil
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		return retry.RetryableError(fmt.Errorf("cluster not yet deleted. Current state: %s", cluster.Status.Status))
+	})
+
+	if err != nil {
+		addClientError(&diags, "Failed to wait for cluster to be deleted.", err)
 	}
 
 	return diags
@@ -1,25 +1,35 @@
 package provider
 
 import (
+	"bytes"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/cassette"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
 )
 
 func TestAccClusterResource(t *testing.T) {
-	rName := acctest.RandomWithPrefix("tftest")
-	backupID := os.Getenv("BACKUP_ID")
+	rec := newTestAccRecorder(t)
+	rName := testAccValue(rec, "cluster_name", func() string { return acctest.RandomWithPrefix(testAccClusterPrefix) })
+	backupID := testAccValue(rec, "backup_id", func() string { return os.Getenv("BACKUP_ID") })
 	testBackup := true
 	if backupID == "" {
 		testBackup = false
 	}
 
 	testPITR := true
-	clusterID := os.Getenv("CLUSTER_ID")
-	targetTime := os.Getenv("TARGET_TIME")
+	clusterID := testAccValue(rec, "cluster_id", func() string { return os.Getenv("CLUSTER_ID") })
+	targetTime := testAccValue(rec, "target_time", func() string { return os.Getenv("TARGET_TIME") })
 	if clusterID == "" {
 		testPITR = false
 	}
@@ -62,6 +72,14 @@ func TestAccClusterResource(t *testing.T) {
 				resource.TestCheckResourceAttr("pgvecto-rs-cloud_cluster.enterprise_plan_cluster", "enable_pooler", "true"),
 			),
 		},
+		// ImportState testing
+		{
+			ResourceName:            "pgvecto-rs-cloud_cluster.enterprise_plan_cluster",
+			ImportState:             true,
+			ImportStateVerify:       true,
+			ImportStateIdFunc:       testAccClusterImportStateIdFunc("pgvecto-rs-cloud_cluster.enterprise_plan_cluster"),
+			ImportStateVerifyIgnore: []string{"timeouts", "adopt_existing"},
+		},
 	}
 
 	if testBackup {
@@ -108,10 +126,20 @@ func TestAccClusterResource(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithRecorder(rec),
 		Steps:                    steps})
 }
 
+func testAccClusterImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["account_id"], rs.Primary.ID), nil
+	}
+}
+
 func testAccClusterResourceConfig(name string) string {
 	return fmt.Sprintf(`
 resource "pgvecto-rs-cloud_cluster" "enterprise_plan_cluster" {
@@ -184,3 +212,1055 @@ resource "pgvecto-rs-cloud_cluster" "enterprise_plan_cluster_pitr" {
 }
 `, name, clusterID, targetTime)
 }
+
+func TestIsClusterGone(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster *client.CNPGCluster
+		err     error
+		want    bool
+	}{
+		{name: "ready", cluster: &client.CNPGCluster{Status: client.CNPGClusterStatus{Status: client.CNPGClusterStatusReady}}, want: false},
+		{name: "deleted", cluster: &client.CNPGCluster{Status: client.CNPGClusterStatus{Status: client.CNPGClusterStatusDeleted}}, want: true},
+		{name: "not found", cluster: &client.CNPGCluster{}, err: &client.Error{StatusCode: http.StatusNotFound}, want: true},
+		{name: "server error", cluster: &client.CNPGCluster{}, err: &client.Error{StatusCode: http.StatusInternalServerError}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isClusterGone(tt.cluster, tt.err); got != tt.want {
+				t.Errorf("isClusterGone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClusterImportID(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		defaultAccount string
+		wantAccount    string
+		wantCluster    string
+		wantErr        bool
+	}{
+		{name: "comma", id: "account,cluster", wantAccount: "account", wantCluster: "cluster"},
+		{name: "slash", id: "account/cluster", wantAccount: "account", wantCluster: "cluster"},
+		{name: "default account", id: "cluster", defaultAccount: "default", wantAccount: "default", wantCluster: "cluster"},
+		{name: "explicit account wins", id: "account,cluster", defaultAccount: "default", wantAccount: "account", wantCluster: "cluster"},
+		{name: "no default account", id: "cluster", wantErr: true},
+		{name: "empty", id: "", defaultAccount: "default", wantErr: true},
+		{name: "empty cluster", id: "account,", wantErr: true},
+		{name: "too many parts", id: "a,b,c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account, cluster, err := parseClusterImportID(tt.id, tt.defaultAccount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseClusterImportID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if account != tt.wantAccount || cluster != tt.wantCluster {
+				t.Errorf("parseClusterImportID() = %q, %q, want %q, %q", account, cluster, tt.wantAccount, tt.wantCluster)
+			}
+		})
+	}
+}
+
+const testClusterType = "pgvecto-rs-cloud_cluster"
+
+// testClusterConfig returns the attributes of a cluster configuration,
+// overridden by attrs.
+func testClusterConfig(p *testProvider, attrs map[string]tftypes.Value) tftypes.Value {
+	config := map[string]tftypes.Value{
+		"cluster_name":      tftypes.NewValue(tftypes.String, "tftest"),
+		"account_id":        tftypes.NewValue(tftypes.String, testAccountID),
+		"plan":              tftypes.NewValue(tftypes.String, "Enterprise"),
+		"image":             tftypes.NewValue(tftypes.String, "16-v0.4.0-extensions-exts"),
+		"server_resource":   tftypes.NewValue(tftypes.String, "aws-m7i-large-2c-8g"),
+		"region":            tftypes.NewValue(tftypes.String, "us-east-1"),
+		"cluster_provider":  tftypes.NewValue(tftypes.String, "aws"),
+		"database_name":     tftypes.NewValue(tftypes.String, "test"),
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "5"),
+		"enable_pooler":     tftypes.NewValue(tftypes.Bool, true),
+	}
+	for name, val := range attrs {
+		config[name] = val
+	}
+	return p.config(testClusterType, config)
+}
+
+func TestClusterResource_Lifecycle(t *testing.T) {
+	p := newTestProvider(t, fake.WithSettleAfter(1))
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+
+	id := stateAttr(t, r.state, "id")
+	stored, ok := p.api.Cluster(testAccountID, id)
+	if !ok {
+		t.Fatalf("cluster %s was not created", id)
+	}
+	if got := stored.Spec.PostgreSQLConfig.Image; got != "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts" {
+		t.Errorf("created image = %q, want %q", got, "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts")
+	}
+	for name, want := range map[string]string{
+		"cluster_name":      "tftest",
+		"account_id":        testAccountID,
+		"plan":              "Enterprise",
+		"image":             "16-v0.4.0-extensions-exts",
+		"server_resource":   "aws-m7i-large-2c-8g",
+		"region":            "us-east-1",
+		"cluster_provider":  "aws",
+		"database_name":     "test",
+		"pg_data_disk_size": "5",
+		"status":            "Ready",
+		"enable_pooler":     "true",
+		"connect_endpoint":  stored.Status.Endpoint.PoolerUserEndpoint,
+	} {
+		if got := stateAttr(t, r.state, name); got != want {
+			t.Errorf("created %s = %q, want %q", name, got, want)
+		}
+	}
+	if r.identity == nil {
+		t.Errorf("created cluster has no identity")
+	}
+
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if r == nil {
+		t.Fatalf("read removed the cluster from state")
+	}
+
+	r, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireNoErrors(t, "update", diags)
+	if got := stateAttr(t, r.state, "pg_data_disk_size"); got != "10" {
+		t.Errorf("updated pg_data_disk_size = %q, want %q", got, "10")
+	}
+	if got := stateAttr(t, r.state, "status"); got != "Ready" {
+		t.Errorf("updated status = %q, want %q", got, "Ready")
+	}
+	if got := p.api.Calls(fake.OpUpgrade); got != 1 {
+		t.Errorf("upgrade requests = %d, want 1", got)
+	}
+
+	// adopt_existing and timeouts only change the configuration.
+	timeoutsType := p.resourceSchema(testClusterType).ValueType().(tftypes.Object).AttributeTypes["timeouts"].(tftypes.Object)
+	r, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+		"adopt_existing":    tftypes.NewValue(tftypes.Bool, true),
+		"timeouts": tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+			"create": tftypes.NewValue(tftypes.String, nil),
+			"update": tftypes.NewValue(tftypes.String, "30m"),
+			"delete": tftypes.NewValue(tftypes.String, nil),
+		}),
+	}))
+	requireNoErrors(t, "update adopt_existing", diags)
+	if got := stateAttr(t, r.state, "adopt_existing"); got != "true" {
+		t.Errorf("updated adopt_existing = %q, want %q", got, "true")
+	}
+	if got := p.api.Calls(fake.OpUpgrade); got != 1 {
+		t.Errorf("upgrade requests = %d, want 1", got)
+	}
+
+	requireNoErrors(t, "destroy", p.destroy(r))
+	if _, ok := p.api.Cluster(testAccountID, id); ok {
+		t.Errorf("cluster %s still exists after destroy", id)
+	}
+}
+
+// TestClusterResource_Replay runs a cluster lifecycle from its cassette in
+// testdata/cassettes, so that replaying is exercised without an API key. Run
+// it with PGVECTORS_CLOUD_CASSETTE=record to record the cassette again,
+// against the fake API. The cassette therefore does not check the fake
+// against the real API, and it does not cover restoring or PITR.
+func TestClusterResource_Replay(t *testing.T) {
+	const recordAPIKey = "pgvectors-record-api-key"
+
+	mode := cassette.ModeReplay
+	if cassette.Mode(os.Getenv("PGVECTORS_CLOUD_CASSETTE")) == cassette.ModeRecord {
+		mode = cassette.ModeRecord
+	}
+	rec := newTestRecorder(t, mode)
+
+	apiKey := "replay"
+	apiURL := rec.Value("api_url", func() string {
+		api := fake.NewServer(fake.WithAPIKey(recordAPIKey), fake.WithSettleAfter(1))
+		t.Cleanup(api.Close)
+		apiKey = recordAPIKey
+		return api.URL
+	})
+	p := newTestProviderWithRecorder(t, rec, apiURL, apiKey)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	for name, want := range map[string]string{
+		"cluster_name":      "tftest",
+		"account_id":        testAccountID,
+		"image":             "16-v0.4.0-extensions-exts",
+		"pg_data_disk_size": "5",
+		"status":            "Ready",
+	} {
+		if got := stateAttr(t, r.state, name); got != want {
+			t.Errorf("created %s = %q, want %q", name, got, want)
+		}
+	}
+
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if r == nil {
+		t.Fatalf("read removed the cluster from state")
+	}
+
+	r, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireNoErrors(t, "update", diags)
+	if got := stateAttr(t, r.state, "pg_data_disk_size"); got != "10" {
+		t.Errorf("updated pg_data_disk_size = %q, want %q", got, "10")
+	}
+
+	requireNoErrors(t, "destroy", p.destroy(r))
+
+	if mode == cassette.ModeReplay {
+		b, err := os.ReadFile(testCassettePath(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(bytes.ToLower(b), []byte("x-api-key")) || bytes.Contains(b, []byte(recordAPIKey)) {
+			t.Errorf("cassette %s holds the API key", testCassettePath(t))
+		}
+	}
+}
+
+func TestClusterResource_ReadDeletedOutOfBand(t *testing.T) {
+	p := newTestProvider(t)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+
+	p.api.RemoveCluster(testAccountID, stateAttr(t, r.state, "id"))
+
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityWarning, "Cluster no longer exists")
+	if r != nil {
+		t.Errorf("read kept the deleted cluster in state")
+	}
+}
+
+func TestClusterResource_ReadRequestPath(t *testing.T) {
+	p := newTestProvider(t)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	reads := len(p.api.Paths(fake.OpGet))
+
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if r == nil {
+		t.Fatalf("read removed the cluster from state")
+	}
+
+	want := "/users/" + testAccountID + "/cnpgs/" + stateAttr(t, r.state, "id")
+	paths := p.api.Paths(fake.OpGet)[reads:]
+	if len(paths) != 1 || paths[0] != want {
+		t.Errorf("read requested %q, want [%q]", paths, want)
+	}
+}
+
+func TestClusterResource_DeleteRequestPath(t *testing.T) {
+	p := newTestProvider(t, fake.WithSettleAfter(1))
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	id := stateAttr(t, r.state, "id")
+	reads := len(p.api.Paths(fake.OpGet))
+
+	requireNoErrors(t, "destroy", p.destroy(r))
+	if _, ok := p.api.Cluster(testAccountID, id); ok {
+		t.Fatalf("cluster %s still exists after destroy", id)
+	}
+
+	want := "/users/" + testAccountID + "/cnpgs/" + id
+	if got := p.api.Paths(fake.OpDelete); len(got) != 1 || got[0] != want {
+		t.Errorf("delete requested %q, want [%q]", got, want)
+	}
+	polls := p.api.Paths(fake.OpGet)[reads:]
+	if len(polls) == 0 {
+		t.Fatalf("destroy did not wait for the deletion")
+	}
+	for _, got := range polls {
+		if got != want {
+			t.Errorf("deletion wait requested %q, want %q", got, want)
+		}
+	}
+}
+
+func TestClusterResource_Import(t *testing.T) {
+	p := newTestProvider(t)
+
+	existing := p.api.AddCluster(testAccountID, client.CNPGCluster{
+		Spec: client.CNPGClusterSpec{
+			Name:            "console",
+			Plan:            client.CNPGClusterPlanStarter,
+			ServerResource:  client.ServerResourceAWST3XLarge,
+			ClusterProvider: client.ClusterProvider{Type: client.AWSCloudProvider, Region: "eu-west-1"},
+			PostgreSQLConfig: client.PostgreSQLConfig{
+				Image:          "modelzai/vchord-cnpg:17-v0.2.0",
+				PGDataDiskSize: "20",
+				VectorConfig:   client.VectorConfig{DatabaseName: "app"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		id       string
+		identity map[string]tftypes.Value
+	}{
+		{name: "id", id: testAccountID + "," + existing.Spec.ID},
+		{name: "cluster id with provider account", id: existing.Spec.ID},
+		{name: "identity", identity: map[string]tftypes.Value{
+			"account_id": tftypes.NewValue(tftypes.String, testAccountID),
+			"cluster_id": tftypes.NewValue(tftypes.String, existing.Spec.ID),
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, diags := p.importState(testClusterType, tt.id, tt.identity)
+			requireNoErrors(t, "import", diags)
+			if r == nil {
+				t.Fatalf("import did not return a cluster")
+			}
+			for name, want := range map[string]string{
+				"id":                existing.Spec.ID,
+				"account_id":        testAccountID,
+				"cluster_name":      "console",
+				"plan":              "Starter",
+				"image":             "17-v0.2.0",
+				"region":            "eu-west-1",
+				"pg_data_disk_size": "20",
+				"database_name":     "app",
+				"status":            "Ready",
+			} {
+				if got := stateAttr(t, r.state, name); got != want {
+					t.Errorf("imported %s = %q, want %q", name, got, want)
+				}
+			}
+			if r.identity == nil {
+				t.Errorf("imported cluster has no identity")
+			}
+		})
+	}
+}
+
+// testIdentity returns the identity of a cluster as a value, failing the test
+// when it has none.
+func testIdentity(t *testing.T, p *testProvider, r *testResource) tftypes.Value {
+	t.Helper()
+	if r.identity == nil {
+		t.Fatalf("cluster has no identity")
+	}
+	return p.value(p.ids.IdentitySchemas[testClusterType].ValueType(), r.identity.IdentityData)
+}
+
+func TestClusterResource_Identity(t *testing.T) {
+	p := newTestProvider(t)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	id := stateAttr(t, r.state, "id")
+
+	identity := testIdentity(t, p, r)
+	for name, want := range map[string]string{
+		"account_id": testAccountID,
+		"cluster_id": id,
+	} {
+		if got := stateAttr(t, identity, name); got != want {
+			t.Errorf("created identity %s = %q, want %q", name, got, want)
+		}
+	}
+
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if r == nil {
+		t.Fatalf("read removed the cluster from state")
+	}
+	if got := testIdentity(t, p, r); !got.Equal(identity) {
+		t.Errorf("read identity = %s, want %s", got, identity)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := identity.As(&attrs); err != nil {
+		t.Fatalf("identity is not an object: %v", err)
+	}
+	imported, diags := p.importState(testClusterType, "", attrs)
+	requireNoErrors(t, "import", diags)
+	if imported == nil {
+		t.Fatalf("import did not return a cluster")
+	}
+	if got := testIdentity(t, p, imported); !got.Equal(identity) {
+		t.Errorf("imported identity = %s, want %s", got, identity)
+	}
+	for _, name := range []string{"id", "account_id", "cluster_name", "plan", "image", "region", "pg_data_disk_size"} {
+		if got, want := stateAttr(t, imported.state, name), stateAttr(t, r.state, name); got != want {
+			t.Errorf("imported %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestClusterResource_APIErrors(t *testing.T) {
+	p := newTestProvider(t)
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpCreate, Status: http.StatusForbidden, Code: 40005, Message: "permission denied"})
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Failed to create cluster")
+	if d.Detail == "" {
+		t.Errorf("create error has no detail")
+	}
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpUpgrade, Status: http.StatusConflict, Code: 40901, Message: "cluster is busy"})
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Failed to upgrade cluster")
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpGet, Status: http.StatusInternalServerError, Code: 50000, Message: "internal error"})
+	_, diags = p.read(r)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to read cluster")
+}
+
+func TestClusterResource_InvalidImage(t *testing.T) {
+	p := newTestProvider(t)
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image": tftypes.NewValue(tftypes.String, "latest"),
+	}))
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Invalid image tag")
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+}
+
+func TestClusterResource_ImageCatalog(t *testing.T) {
+	p := newTestProvider(t)
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image": tftypes.NewValue(tftypes.String, "16-v0.4.1-extensions-exts"),
+	}))
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unknown image")
+	if !strings.Contains(d.Detail, "Did you mean 16-v0.4.0-extensions-exts?") {
+		t.Errorf("detail = %q, want a suggestion of 16-v0.4.0-extensions-exts", d.Detail)
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image": tftypes.NewValue(tftypes.String, "16-v0.3.0-extensions-exts"),
+	}))
+	requireNoErrors(t, "create", diags)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityWarning, "Deprecated image")
+
+	// An image removed from the catalog does not block plans of the
+	// clusters already using it.
+	p.api.SetImages(nil)
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"image":             tftypes.NewValue(tftypes.String, "16-v0.3.0-extensions-exts"),
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireNoErrors(t, "update", diags)
+
+	if got := p.api.Calls(fake.OpListImages); got != 1 {
+		t.Errorf("image catalog requests = %d, want 1", got)
+	}
+}
+
+func TestClusterResource_ImageCatalogUnavailable(t *testing.T) {
+	p := newTestProvider(t)
+	p.api.InjectFault(fake.Fault{Op: fake.OpListImages, Status: http.StatusServiceUnavailable, Times: -1})
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image": tftypes.NewValue(tftypes.String, "16-v0.5.0-extensions-exts"),
+	}))
+	requireNoErrors(t, "create", diags)
+
+	_, diags = p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"cluster_name": tftypes.NewValue(tftypes.String, "tftest-latest"),
+		"image":        tftypes.NewValue(tftypes.String, "latest"),
+	}))
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Invalid image tag")
+}
+
+func TestClusterResource_ImageReference(t *testing.T) {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name       string
+		attrs      map[string]tftypes.Value
+		wantImage  string
+		wantFamily string
+		wantAPI    string
+	}{
+		{
+			name:       "tag",
+			attrs:      map[string]tftypes.Value{"image": tftypes.NewValue(tftypes.String, "17-v0.2.0")},
+			wantImage:  "17-v0.2.0",
+			wantFamily: "vectorchord",
+			wantAPI:    "modelzai/vchord-cnpg:17-v0.2.0",
+		},
+		{
+			name:       "digest",
+			attrs:      map[string]tftypes.Value{"image": tftypes.NewValue(tftypes.String, "modelzai/vchord-cnpg:17-v0.2.0@"+digest)},
+			wantImage:  "modelzai/vchord-cnpg:17-v0.2.0@" + digest,
+			wantFamily: "vectorchord",
+			wantAPI:    "modelzai/vchord-cnpg:17-v0.2.0@" + digest,
+		},
+		{
+			name: "mirror",
+			attrs: map[string]tftypes.Value{
+				"image":        tftypes.NewValue(tftypes.String, "registry.example.com:5000/postgres/custom:16-v0.4.0"),
+				"image_family": tftypes.NewValue(tftypes.String, "pgvecto.rs"),
+			},
+			wantImage:  "registry.example.com:5000/postgres/custom:16-v0.4.0",
+			wantFamily: "pgvecto.rs",
+			wantAPI:    "registry.example.com:5000/postgres/custom:16-v0.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProvider(t)
+
+			r, diags := p.apply(testClusterType, nil, testClusterConfig(p, tt.attrs))
+			requireNoErrors(t, "create", diags)
+			c, ok := p.api.Cluster(testAccountID, stateAttr(t, r.state, "id"))
+			if !ok {
+				t.Fatalf("cluster was not created")
+			}
+			if got := c.Spec.PostgreSQLConfig.Image; got != tt.wantAPI {
+				t.Errorf("API image = %q, want %q", got, tt.wantAPI)
+			}
+
+			r, diags = p.read(r)
+			requireNoErrors(t, "read", diags)
+			if got := stateAttr(t, r.state, "image"); got != tt.wantImage {
+				t.Errorf("image = %q, want %q", got, tt.wantImage)
+			}
+			if got := stateAttr(t, r.state, "image_family"); got != tt.wantFamily {
+				t.Errorf("image_family = %q, want %q", got, tt.wantFamily)
+			}
+		})
+	}
+}
+
+func TestClusterResource_ImageFamilyRequired(t *testing.T) {
+	p := newTestProvider(t)
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image": tftypes.NewValue(tftypes.String, "registry.example.com/postgres/custom:16"),
+	}))
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Invalid image")
+	if !strings.Contains(d.Detail, "image_family") {
+		t.Errorf("detail = %q, want a hint to set image_family", d.Detail)
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+}
+
+func TestClusterResource_ImageFamilyConflict(t *testing.T) {
+	p := newTestProvider(t)
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image":        tftypes.NewValue(tftypes.String, "modelzai/vchord-cnpg:17-v0.2.0"),
+		"image_family": tftypes.NewValue(tftypes.String, "pgvecto.rs"),
+	}))
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Conflicting image family")
+	if want := tftypes.NewAttributePath().WithAttributeName("image_family"); !d.Attribute.Equal(want) {
+		t.Errorf("diagnostic attribute = %s, want %s", d.Attribute, want)
+	}
+	if !strings.Contains(d.Detail, "vectorchord") {
+		t.Errorf("detail = %q, want the family of the image", d.Detail)
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"image":        tftypes.NewValue(tftypes.String, "modelzai/vchord-cnpg:17-v0.2.0"),
+		"image_family": tftypes.NewValue(tftypes.String, "vectorchord"),
+	}))
+	requireNoErrors(t, "create", diags)
+	if got := stateAttr(t, r.state, "image_family"); got != "vectorchord" {
+		t.Errorf("image_family = %q, want %q", got, "vectorchord")
+	}
+}
+
+func TestClusterResource_ImageFamilyReplace(t *testing.T) {
+	p := newTestProvider(t)
+
+	const mirror = "registry.example.com:5000/postgres/custom:16-v0.4.0"
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	mirrored, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"cluster_name": tftypes.NewValue(tftypes.String, "mirrored"),
+		"image":        tftypes.NewValue(tftypes.String, mirror),
+		"image_family": tftypes.NewValue(tftypes.String, "pgvecto.rs"),
+	}))
+	requireNoErrors(t, "create", diags)
+
+	tests := []struct {
+		name        string
+		prior       *testResource
+		attrs       map[string]tftypes.Value
+		wantReplace bool
+	}{
+		{
+			name:  "upgrade",
+			prior: r,
+			attrs: map[string]tftypes.Value{
+				"image": tftypes.NewValue(tftypes.String, "16-v0.3.0-extensions-exts"),
+			},
+		},
+		{
+			name:  "tag of another family",
+			prior: r,
+			attrs: map[string]tftypes.Value{
+				"image": tftypes.NewValue(tftypes.String, "17-v0.2.0"),
+			},
+			wantReplace: true,
+		},
+		{
+			name:  "family of a mirrored image",
+			prior: mirrored,
+			attrs: map[string]tftypes.Value{
+				"cluster_name": tftypes.NewValue(tftypes.String, "mirrored"),
+				"image":        tftypes.NewValue(tftypes.String, mirror),
+				"image_family": tftypes.NewValue(tftypes.String, "vectorchord"),
+			},
+			wantReplace: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := p.plan(testClusterType, tt.prior, testClusterConfig(p, tt.attrs))
+			requireNoErrors(t, "plan", resp.Diagnostics)
+
+			replace := false
+			for _, path := range resp.RequiresReplace {
+				if path.Equal(tftypes.NewAttributePath().WithAttributeName("image_family")) {
+					replace = true
+				}
+			}
+			if replace != tt.wantReplace {
+				t.Errorf("image_family requires replace = %t, want %t", replace, tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestClusterResource_ServerResourceCatalog(t *testing.T) {
+	p := newTestProvider(t)
+
+	tests := []struct {
+		name        string
+		attrs       map[string]tftypes.Value
+		wantSummary string
+		wantDetail  string
+	}{
+		{
+			name:        "typo",
+			attrs:       map[string]tftypes.Value{"server_resource": tftypes.NewValue(tftypes.String, "aws-m7i-large-2c-9g")},
+			wantSummary: "Unknown server resource",
+			wantDetail:  "Did you mean aws-m7i-large-2c-8g?",
+		},
+		{
+			name:        "plan",
+			attrs:       map[string]tftypes.Value{"plan": tftypes.NewValue(tftypes.String, "Starter")},
+			wantSummary: "Server resource not available with plan",
+			wantDetail:  "runs shared clusters on aws-t3-xlarge-4c-16g",
+		},
+		{
+			name: "region",
+			attrs: map[string]tftypes.Value{
+				"server_resource": tftypes.NewValue(tftypes.String, "aws-i4i-xlarge-4c-32g"),
+				"region":          tftypes.NewValue(tftypes.String, "eu-west-1"),
+			},
+			wantSummary: "Server resource not available in region",
+			wantDetail:  "only in us-east-1",
+		},
+		{
+			name:        "unknown region",
+			attrs:       map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "us-east-2")},
+			wantSummary: "Unknown region",
+			wantDetail:  "Did you mean us-east-1?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diags := p.apply(testClusterType, nil, testClusterConfig(p, tt.attrs))
+			d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tt.wantSummary)
+			if !strings.Contains(d.Detail, tt.wantDetail) {
+				t.Errorf("detail = %q, want it to contain %q", d.Detail, tt.wantDetail)
+			}
+		})
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+
+	// Instance types are not limited to a fixed list.
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"server_resource": tftypes.NewValue(tftypes.String, "aws-i4i-xlarge-4c-32g"),
+	}))
+	requireNoErrors(t, "create", diags)
+	if got := stateAttr(t, r.state, "server_resource"); got != "aws-i4i-xlarge-4c-32g" {
+		t.Errorf("server_resource = %q, want aws-i4i-xlarge-4c-32g", got)
+	}
+
+	if got := p.api.Calls(fake.OpListServerResources); got != 1 {
+		t.Errorf("server resource catalog requests = %d, want 1", got)
+	}
+}
+
+func TestClusterResource_CatalogsUnavailable(t *testing.T) {
+	p := newTestProvider(t)
+	p.api.InjectFault(fake.Fault{Op: fake.OpListServerResources, Status: http.StatusServiceUnavailable, Times: -1})
+	p.api.InjectFault(fake.Fault{Op: fake.OpListRegions, Status: http.StatusServiceUnavailable, Times: -1})
+	p.api.InjectFault(fake.Fault{Op: fake.OpListPlans, Status: http.StatusServiceUnavailable, Times: -1})
+
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"server_resource": tftypes.NewValue(tftypes.String, "aws-c7i-large-2c-4g"),
+	}))
+	requireNoErrors(t, "create", diags)
+}
+
+func TestClusterResource_PlanCapabilities(t *testing.T) {
+	p := newTestProvider(t)
+
+	starter := map[string]tftypes.Value{
+		"plan":            tftypes.NewValue(tftypes.String, "Starter"),
+		"server_resource": tftypes.NewValue(tftypes.String, "aws-t3-xlarge-4c-16g"),
+		"enable_pooler":   tftypes.NewValue(tftypes.Bool, false),
+	}
+	with := func(base map[string]tftypes.Value, attrs map[string]tftypes.Value) map[string]tftypes.Value {
+		merged := map[string]tftypes.Value{}
+		for name, val := range base {
+			merged[name] = val
+		}
+		for name, val := range attrs {
+			merged[name] = val
+		}
+		return merged
+	}
+
+	tests := []struct {
+		name        string
+		attrs       map[string]tftypes.Value
+		wantSummary string
+		wantDetail  string
+	}{
+		{
+			name:        "unknown plan",
+			attrs:       map[string]tftypes.Value{"plan": tftypes.NewValue(tftypes.String, "Enterprize")},
+			wantSummary: "Unknown plan",
+			wantDetail:  "Did you mean Enterprise?",
+		},
+		{
+			name:        "pooler",
+			attrs:       with(starter, map[string]tftypes.Value{"enable_pooler": tftypes.NewValue(tftypes.Bool, true)}),
+			wantSummary: "Pooler not available with plan",
+			wantDetail:  "The Starter plan does not support the connection pooler",
+		},
+		{
+			name: "pitr",
+			attrs: with(starter, map[string]tftypes.Value{
+				"enable_restore":    tftypes.NewValue(tftypes.Bool, true),
+				"target_cluster_id": tftypes.NewValue(tftypes.String, "source"),
+				"target_time":       tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+			}),
+			wantSummary: "Point-in-time recovery not available with plan",
+			wantDetail:  "Restore from a backup_id instead",
+		},
+		{
+			name:        "disk size",
+			attrs:       with(starter, map[string]tftypes.Value{"pg_data_disk_size": tftypes.NewValue(tftypes.String, "100")}),
+			wantSummary: "Disk size not available with plan",
+			wantDetail:  "between 1 and 64 GB, got 100 GB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diags := p.apply(testClusterType, nil, testClusterConfig(p, tt.attrs))
+			d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, tt.wantSummary)
+			if !strings.Contains(d.Detail, tt.wantDetail) {
+				t.Errorf("detail = %q, want it to contain %q", d.Detail, tt.wantDetail)
+			}
+		})
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+		"enable_pooler":     tftypes.NewValue(tftypes.Bool, false),
+	}))
+	requireNoErrors(t, "create", diags)
+
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "5"),
+		"enable_pooler":     tftypes.NewValue(tftypes.Bool, false),
+	}))
+	d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Disk shrink not supported")
+	if !strings.Contains(d.Detail, "from 10 to 5 GB") {
+		t.Errorf("detail = %q, want it to mention the sizes", d.Detail)
+	}
+	if got := p.api.Calls(fake.OpUpgrade); got != 0 {
+		t.Errorf("upgrade requests = %d, want 0", got)
+	}
+
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, with(starter, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	})))
+	requireNoErrors(t, "downgrade", diags)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityWarning, "Plan without high availability")
+}
+
+func TestClusterResource_CatalogOnlyPlan(t *testing.T) {
+	p := newTestProvider(t)
+	const team client.CNPGClusterPlan = "Team"
+	p.api.SetPlans(append(fake.DefaultPlans, client.CNPGClusterPlanInfo{
+		Plan:            team,
+		Type:            client.CNPGClusterTypeDedicated,
+		ServerResources: []client.ServerResource{client.ServerResourceAWSM7ILarge},
+		MinDiskSize:     1,
+		MaxDiskSize:     1024,
+		Features:        client.CNPGClusterPlanFeatures{Pooler: true},
+	}))
+	var regions []client.RegionInfo
+	for _, region := range fake.DefaultRegions {
+		region.Plans = append(region.Plans[:len(region.Plans):len(region.Plans)], team)
+		regions = append(regions, region)
+	}
+	p.api.SetRegions(regions)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"plan": tftypes.NewValue(tftypes.String, string(team)),
+	}))
+	requireNoErrors(t, "create", diags)
+	c, ok := p.api.Cluster(testAccountID, stateAttr(t, r.state, "id"))
+	if !ok {
+		t.Fatalf("cluster was not created")
+	}
+	if c.Spec.Plan != team {
+		t.Errorf("API plan = %q, want %q", c.Spec.Plan, team)
+	}
+}
+
+func TestClusterResource_PartialPlanCatalog(t *testing.T) {
+	p := newTestProvider(t)
+	p.api.SetPlans([]client.CNPGClusterPlanInfo{
+		{
+			Plan:        client.CNPGClusterPlanStarter,
+			Type:        client.CNPGClusterTypeShared,
+			MaxDiskSize: 64,
+		},
+		{
+			Plan:        client.CNPGClusterPlanEnterprise,
+			Type:        client.CNPGClusterTypeDedicated,
+			MinDiskSize: 10,
+			Features:    client.CNPGClusterPlanFeatures{Pooler: true, PITR: true, HighAvailability: true},
+		},
+	})
+
+	tests := []struct {
+		name       string
+		attrs      map[string]tftypes.Value
+		wantDetail string
+	}{
+		{
+			name:       "minimum only",
+			attrs:      map[string]tftypes.Value{"pg_data_disk_size": tftypes.NewValue(tftypes.String, "5")},
+			wantDetail: "must be at least 10 GB, got 5 GB",
+		},
+		{
+			name: "maximum only",
+			attrs: map[string]tftypes.Value{
+				"plan":              tftypes.NewValue(tftypes.String, "Starter"),
+				"server_resource":   tftypes.NewValue(tftypes.String, "aws-t3-xlarge-4c-16g"),
+				"enable_pooler":     tftypes.NewValue(tftypes.Bool, false),
+				"pg_data_disk_size": tftypes.NewValue(tftypes.String, "100"),
+			},
+			wantDetail: "must be at most 64 GB, got 100 GB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, diags := p.apply(testClusterType, nil, testClusterConfig(p, tt.attrs))
+			d := requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Disk size not available with plan")
+			if !strings.Contains(d.Detail, tt.wantDetail) {
+				t.Errorf("detail = %q, want it to contain %q", d.Detail, tt.wantDetail)
+			}
+		})
+	}
+
+	// The plans do not list their server resources, so any is accepted.
+	_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "20"),
+	}))
+	requireNoErrors(t, "create", diags)
+}
+
+func TestClusterResource_DiskSize(t *testing.T) {
+	p := newTestProvider(t)
+
+	for _, size := range []string{"0", "20000", "10Mi"} {
+		_, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+			"pg_data_disk_size": tftypes.NewValue(tftypes.String, size),
+		}))
+		requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Invalid disk size")
+	}
+	if got := p.api.Calls(fake.OpCreate); got != 0 {
+		t.Errorf("create requests = %d, want 0", got)
+	}
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10Gi"),
+	}))
+	requireNoErrors(t, "create", diags)
+	id := stateAttr(t, r.state, "id")
+	c, _ := p.api.Cluster(testAccountID, id)
+	if got := c.Spec.PostgreSQLConfig.PGDataDiskSize; got != "10" {
+		t.Errorf("API disk size = %q, want %q", got, "10")
+	}
+
+	// The API reports sizes with a unit, which must not replace the
+	// configured value.
+	c.Spec.PostgreSQLConfig.PGDataDiskSize = "10Gi"
+	p.api.AddCluster(testAccountID, c)
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if got := stateAttr(t, r.state, "pg_data_disk_size"); got != "10Gi" {
+		t.Errorf("pg_data_disk_size after refresh = %q, want %q", got, "10Gi")
+	}
+
+	r, diags = p.importState(testClusterType, testAccountID+","+id, nil)
+	requireNoErrors(t, "import", diags)
+	if got := stateAttr(t, r.state, "pg_data_disk_size"); got != "10" {
+		t.Errorf("imported pg_data_disk_size = %q, want %q", got, "10")
+	}
+
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "9Gi"),
+	}))
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Disk shrink not supported")
+}
+
+func TestClusterResource_DiskSizeFromAPI(t *testing.T) {
+	p := newTestProvider(t)
+
+	// The API leaves the disk size empty when it is not set.
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, nil),
+	}))
+	requireNoErrors(t, "create", diags)
+	if got := stateAttr(t, r.state, "pg_data_disk_size"); got != "<null>" {
+		t.Errorf("created pg_data_disk_size = %q, want %q", got, "<null>")
+	}
+
+	c, _ := p.api.Cluster(testAccountID, stateAttr(t, r.state, "id"))
+	for _, tt := range []struct {
+		api  string
+		want string
+	}{
+		{api: "5G", want: "5"},
+		{api: " 20 GB", want: "20"},
+		{api: "0", want: "0"},
+		{api: "", want: "<null>"},
+	} {
+		c.Spec.PostgreSQLConfig.PGDataDiskSize = tt.api
+		p.api.AddCluster(testAccountID, c)
+		read, diags := p.read(r)
+		requireNoErrors(t, "read", diags)
+		if got := stateAttr(t, read.state, "pg_data_disk_size"); got != tt.want {
+			t.Errorf("pg_data_disk_size read from %q = %q, want %q", tt.api, got, tt.want)
+		}
+	}
+}
+
+func TestClusterResource_Suspend(t *testing.T) {
+	p := newTestProvider(t, fake.WithSettleAfter(1))
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, nil))
+	requireNoErrors(t, "create", diags)
+	if got := stateAttr(t, r.state, "suspended"); got != "false" {
+		t.Errorf("suspended after create = %q, want false", got)
+	}
+
+	suspended := tftypes.NewValue(tftypes.Bool, true)
+	r, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{"suspended": suspended}))
+	requireNoErrors(t, "suspend", diags)
+	if got := stateAttr(t, r.state, "status"); got != "Suspended" {
+		t.Errorf("status after suspend = %q, want Suspended", got)
+	}
+	if got := p.api.Calls(fake.OpUpgrade); got != 0 {
+		t.Errorf("upgrade requests = %d, want 0", got)
+	}
+
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"suspended":         suspended,
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Cluster is suspended")
+
+	// Resuming and upgrading in one apply resumes the cluster first.
+	r, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"suspended":         tftypes.NewValue(tftypes.Bool, false),
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireNoErrors(t, "resume", diags)
+	for name, want := range map[string]string{
+		"suspended":         "false",
+		"status":            "Ready",
+		"pg_data_disk_size": "10",
+	} {
+		if got := stateAttr(t, r.state, name); got != want {
+			t.Errorf("%s after resume = %q, want %q", name, got, want)
+		}
+	}
+
+	// Without the attribute, a cluster suspended outside of Terraform is
+	// left suspended.
+	p.api.SetStatus(testAccountID, stateAttr(t, r.state, "id"), client.CNPGClusterStatusSuspended)
+	r, diags = p.read(r)
+	requireNoErrors(t, "read", diags)
+	if got := stateAttr(t, r.state, "suspended"); got != "true" {
+		t.Errorf("suspended after refresh = %q, want true", got)
+	}
+	_, diags = p.apply(testClusterType, r, testClusterConfig(p, map[string]tftypes.Value{
+		"pg_data_disk_size": tftypes.NewValue(tftypes.String, "10"),
+	}))
+	requireNoErrors(t, "unmanaged", diags)
+
+	for op, want := range map[fake.Op]int{fake.OpSuspend: 1, fake.OpResume: 1, fake.OpUpgrade: 1} {
+		if got := p.api.Calls(op); got != want {
+			t.Errorf("%s requests = %d, want %d", op, got, want)
+		}
+	}
+}
+
+func TestClusterResource_CreateSuspended(t *testing.T) {
+	p := newTestProvider(t)
+
+	r, diags := p.apply(testClusterType, nil, testClusterConfig(p, map[string]tftypes.Value{
+		"suspended": tftypes.NewValue(tftypes.Bool, true),
+	}))
+	requireNoErrors(t, "create", diags)
+	if got := stateAttr(t, r.state, "status"); got != "Suspended" {
+		t.Errorf("status = %q, want Suspended", got)
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClustersDataSource{}
+var _ datasource.DataSourceWithConfigure = &ClustersDataSource{}
+
+func NewClustersDataSource() datasource.DataSource {
+	return &ClustersDataSource{}
+}
+
+// ClustersDataSource defines the data source listing the clusters of an account.
+type ClustersDataSource struct {
+	client *client.Client
+}
+
+// ClustersDataSourceModel describes the clusters data model.
+type ClustersDataSourceModel struct {
+	AccountId  types.String             `tfsdk:"account_id"`
+	NamePrefix types.String             `tfsdk:"name_prefix"`
+	Region     types.String             `tfsdk:"region"`
+	Plan       types.String             `tfsdk:"plan"`
+	Status     types.String             `tfsdk:"status"`
+	Clusters   []ClusterDataSourceModel `tfsdk:"clusters"`
+}
+
+func (d *ClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (d *ClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clusters Data Source. Lists the clusters of an account, optionally filtered by name prefix, region, plan or status.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Default Account Identifier for the PGVecto.rs cloud",
+				Required:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters in this region, e.g. us-east-1.",
+				Optional:            true,
+			},
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters on this plan. Available options are Starter and Enterprise.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters in this status, e.g. Ready.",
+				Optional:            true,
+			},
+			"clusters": schema.ListNestedAttribute{
+				MarkdownDescription: "The clusters matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: clusterDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ClustersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "sending list clusters request...")
+	clusters, err := d.client.ListAllClusters(ctx, state.AccountId.ValueString(), client.ListClustersOptions{
+		NamePrefix: state.NamePrefix.ValueString(),
+		Region:     state.Region.ValueString(),
+		Plan:       client.CNPGClusterPlan(state.Plan.ValueString()),
+		Status:     client.ClusterStatus(state.Status.ValueString()),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list clusters", err)
+		return
+	}
+
+	state.Clusters = make([]ClusterDataSourceModel, 0, len(clusters))
+	for i := range clusters {
+		// Deleted clusters are still listed for a while, but they cannot be
+		// used anymore.
+		if clusters[i].Status.Status == client.CNPGClusterStatusDeleted {
+			continue
+		}
+		var cluster ClusterDataSourceModel
+		cluster.AccountId = state.AccountId
+		cluster.setCluster(&clusters[i])
+		state.Clusters = append(state.Clusters, cluster)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+// addTestCluster stores a cluster created outside of Terraform in the fake
+// API and returns it.
+func addTestCluster(p *testProvider, userID, name string, plan client.CNPGClusterPlan, region string, status client.ClusterStatus) client.CNPGCluster {
+	serverResource := client.ServerResourceAWSM7ILarge
+	if plan == client.CNPGClusterPlanStarter {
+		serverResource = client.ServerResourceAWST3XLarge
+	}
+	return p.api.AddCluster(userID, client.CNPGCluster{
+		Spec: client.CNPGClusterSpec{
+			Name:            name,
+			Plan:            plan,
+			ServerResource:  serverResource,
+			ClusterProvider: client.ClusterProvider{Type: client.AWSCloudProvider, Region: region},
+			PostgreSQLConfig: client.PostgreSQLConfig{
+				Image:          "modelzai/pgvecto-rs:16-v0.4.0-extensions-exts",
+				PGDataDiskSize: "5",
+				EnablePooler:   plan == client.CNPGClusterPlanEnterprise,
+				VectorConfig:   client.VectorConfig{DatabaseName: "test"},
+			},
+		},
+		Status: client.CNPGClusterStatus{Status: status},
+	})
+}
+
+func TestClustersDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+
+	app := addTestCluster(p, testAccountID, "app-prod", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusReady)
+	addTestCluster(p, testAccountID, "app-dev", client.CNPGClusterPlanStarter, "eu-west-1", client.CNPGClusterStatusReady)
+	addTestCluster(p, testAccountID, "app-staging", client.CNPGClusterPlanEnterprise, "eu-west-1", client.CNPGClusterStatusSuspended)
+	addTestCluster(p, testAccountID, "search", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusReady)
+	addTestCluster(p, testAccountID, "app-old", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusDeleted)
+	addTestCluster(p, "another-account", "app-other", client.CNPGClusterPlanEnterprise, "us-east-1", client.CNPGClusterStatusReady)
+
+	tests := []struct {
+		name      string
+		attrs     map[string]tftypes.Value
+		wantNames string
+	}{
+		{
+			name:      "all",
+			wantNames: "app-prod,app-dev,app-staging,search",
+		},
+		{
+			name: "name prefix",
+			attrs: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "app-"),
+			},
+			wantNames: "app-prod,app-dev,app-staging",
+		},
+		{
+			name: "region",
+			attrs: map[string]tftypes.Value{
+				"region": tftypes.NewValue(tftypes.String, "eu-west-1"),
+			},
+			wantNames: "app-dev,app-staging",
+		},
+		{
+			name: "plan",
+			attrs: map[string]tftypes.Value{
+				"plan": tftypes.NewValue(tftypes.String, "Starter"),
+			},
+			wantNames: "app-dev",
+		},
+		{
+			name: "status",
+			attrs: map[string]tftypes.Value{
+				"status": tftypes.NewValue(tftypes.String, "Suspended"),
+			},
+			wantNames: "app-staging",
+		},
+		{
+			name: "combined",
+			attrs: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "app-"),
+				"region":      tftypes.NewValue(tftypes.String, "us-east-1"),
+				"plan":        tftypes.NewValue(tftypes.String, "Enterprise"),
+				"status":      tftypes.NewValue(tftypes.String, "Ready"),
+			},
+			wantNames: "app-prod",
+		},
+		{
+			name: "no match",
+			attrs: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "missing"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := map[string]tftypes.Value{
+				"account_id": tftypes.NewValue(tftypes.String, testAccountID),
+			}
+			for name, val := range tt.attrs {
+				attrs[name] = val
+			}
+			state, diags := p.readDataSource("pgvecto-rs-cloud_clusters", attrs)
+			requireNoErrors(t, "read", diags)
+
+			var names []string
+			for _, cluster := range listAttr(t, state, "clusters") {
+				names = append(names, stateAttr(t, cluster, "cluster_name"))
+			}
+			if got := strings.Join(names, ","); got != tt.wantNames {
+				t.Errorf("clusters = %q, want %q", got, tt.wantNames)
+			}
+		})
+	}
+
+	state, diags := p.readDataSource("pgvecto-rs-cloud_clusters", map[string]tftypes.Value{
+		"account_id":  tftypes.NewValue(tftypes.String, testAccountID),
+		"name_prefix": tftypes.NewValue(tftypes.String, "app-prod"),
+	})
+	requireNoErrors(t, "read", diags)
+	clusters := listAttr(t, state, "clusters")
+	if len(clusters) != 1 {
+		t.Fatalf("clusters = %d, want 1", len(clusters))
+	}
+	for name, want := range map[string]string{
+		"id":                app.Spec.ID,
+		"account_id":        testAccountID,
+		"cluster_name":      "app-prod",
+		"plan":              "Enterprise",
+		"image":             "16-v0.4.0-extensions-exts",
+		"image_family":      "pgvecto.rs",
+		"server_resource":   "aws-m7i-large-2c-8g",
+		"region":            "us-east-1",
+		"cluster_provider":  "aws",
+		"status":            "Ready",
+		"pg_data_disk_size": "5",
+		"database_name":     "test",
+		"enable_pooler":     "true",
+		"connect_endpoint":  app.Status.Endpoint.PoolerUserEndpoint,
+	} {
+		if got := stateAttr(t, clusters[0], name); got != want {
+			t.Errorf("clusters[0].%s = %q, want %q", name, got, want)
+		}
+	}
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpList, Status: 500, Code: 50000, Message: "internal error"})
+	_, diags = p.readDataSource("pgvecto-rs-cloud_clusters", map[string]tftypes.Value{
+		"account_id": tftypes.NewValue(tftypes.String, testAccountID),
+	})
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list clusters")
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// addClientError adds an error diagnostic for a failed API call. The detail
+// carries the API message, the request ID and a hint on how to fix it.
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	diags.AddError(summary, clientErrorDetail(err))
+}
+
+func clientErrorDetail(err error) string {
+	detail := err.Error()
+
+	var apiErr *client.Error
+	if errors.As(err, &apiErr) && apiErr.Message != "" {
+		detail = apiErr.Message
+		if apiErr.RequestID != "" {
+			detail = fmt.Sprintf("%s (request ID: %s)", detail, apiErr.RequestID)
+		}
+	}
+
+	var hint string
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		hint = "Check that the api_key provider setting or the PGVECTORS_CLOUD_API_KEY environment variable holds a valid API key."
+	case errors.Is(err, client.ErrPermissionDenied):
+		hint = "The API key is not allowed to access this resource. Check that account_id is the account the API key was created for."
+	case errors.Is(err, client.ErrNotFound):
+		hint = "The resource does not exist or was deleted outside of Terraform."
+	case errors.Is(err, client.ErrConflict):
+		hint = "The resource already exists or is being changed by another operation. Wait for it to settle and try again."
+	case errors.Is(err, client.ErrQuotaExceeded):
+		hint = "The account has reached one of its limits. Delete unused clusters or contact PGVecto.rs Cloud support to raise it."
+	}
+
+	if hint == "" {
+		return detail
+	}
+	return detail + "\n\n" + hint
+}
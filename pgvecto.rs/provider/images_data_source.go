@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ImagesDataSource{}
+var _ datasource.DataSourceWithConfigure = &ImagesDataSource{}
+
+func NewImagesDataSource() datasource.DataSource {
+	return &ImagesDataSource{}
+}
+
+// ImagesDataSource defines the data source listing the images clusters can
+// be created with.
+type ImagesDataSource struct {
+	client *client.Client
+}
+
+// ImagesDataSourceModel describes the images data model.
+type ImagesDataSourceModel struct {
+	PGMajorVersion    types.Int64  `tfsdk:"pg_major_version"`
+	Extension         types.String `tfsdk:"extension"`
+	IncludeDeprecated types.Bool   `tfsdk:"include_deprecated"`
+	Latest            types.String `tfsdk:"latest"`
+	Images            []ImageModel `tfsdk:"images"`
+}
+
+// ImageModel describes an image of the images data source.
+type ImageModel struct {
+	Tag              types.String `tfsdk:"tag"`
+	Image            types.String `tfsdk:"image"`
+	PGMajorVersion   types.Int64  `tfsdk:"pg_major_version"`
+	Extension        types.String `tfsdk:"extension"`
+	ExtensionVersion types.String `tfsdk:"extension_version"`
+	Deprecated       types.Bool   `tfsdk:"deprecated"`
+}
+
+func (d *ImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_images"
+}
+
+func (d *ImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Images Data Source. Lists the images clusters can be created with, optionally filtered by PostgreSQL major version and extension, and selects the latest one.",
+		Attributes: map[string]schema.Attribute{
+			"pg_major_version": schema.Int64Attribute{
+				MarkdownDescription: "Only list images of this PostgreSQL major version, e.g. 16.",
+				Optional:            true,
+			},
+			"extension": schema.StringAttribute{
+				MarkdownDescription: "Only list images shipping this vector extension. Available options are pgvecto.rs and vectorchord.",
+				Optional:            true,
+			},
+			"include_deprecated": schema.BoolAttribute{
+				MarkdownDescription: "Also list deprecated images. Defaults to false.",
+				Optional:            true,
+			},
+			"latest": schema.StringAttribute{
+				MarkdownDescription: "The tag of the newest image matching the filters that is not deprecated, to be used as the `image` of a cluster. VectorChord images are preferred over pgvecto.rs images of the same PostgreSQL major version unless `extension` is set. Null when no image matches.",
+				Computed:            true,
+			},
+			"images": schema.ListNestedAttribute{
+				MarkdownDescription: "The images matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tag": schema.StringAttribute{
+							MarkdownDescription: "The tag of the image, to be used as the `image` of a cluster.",
+							Computed:            true,
+						},
+						"image": schema.StringAttribute{
+							MarkdownDescription: "The full image reference, e.g. modelzai/pgvecto-rs:16-v0.4.0-extensions-exts.",
+							Computed:            true,
+						},
+						"pg_major_version": schema.Int64Attribute{
+							MarkdownDescription: "The PostgreSQL major version of the image.",
+							Computed:            true,
+						},
+						"extension": schema.StringAttribute{
+							MarkdownDescription: "The vector extension shipped in the image, pgvecto.rs or vectorchord.",
+							Computed:            true,
+						},
+						"extension_version": schema.StringAttribute{
+							MarkdownDescription: "The version of the vector extension, e.g. v0.4.0.",
+							Computed:            true,
+						},
+						"deprecated": schema.BoolAttribute{
+							MarkdownDescription: "Whether the image is deprecated. Deprecated images will be removed and should not be used for new clusters.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ImagesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	extension := client.ImageExtension(state.Extension.ValueString())
+	switch extension {
+	case "", client.ImageExtensionPGVectoRS, client.ImageExtensionVectorChord:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("extension"), "Invalid extension",
+			fmt.Sprintf("Invalid extension: %s. Available options are %s and %s.", extension, client.ImageExtensionPGVectoRS, client.ImageExtensionVectorChord))
+		return
+	}
+	pgMajor := int(state.PGMajorVersion.ValueInt64())
+
+	tflog.Trace(ctx, "sending list images request...")
+	images, err := d.client.ListImages(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list images", err)
+		return
+	}
+
+	state.Images = make([]ImageModel, 0, len(images))
+	for _, image := range images {
+		if (pgMajor != 0 && image.PGMajorVersion != pgMajor) ||
+			(extension != "" && image.Extension != extension) ||
+			(image.Deprecated && !state.IncludeDeprecated.ValueBool()) {
+			continue
+		}
+		state.Images = append(state.Images, ImageModel{
+			Tag:              types.StringValue(image.Tag),
+			Image:            types.StringValue(image.Image),
+			PGMajorVersion:   types.Int64Value(int64(image.PGMajorVersion)),
+			Extension:        types.StringValue(string(image.Extension)),
+			ExtensionVersion: types.StringValue(image.ExtensionVersion),
+			Deprecated:       types.BoolValue(image.Deprecated),
+		})
+	}
+
+	state.Latest = types.StringNull()
+	if latest, ok := client.LatestImage(images, pgMajor, extension); ok {
+		state.Latest = types.StringValue(latest.Tag)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+func TestImagesDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+
+	tests := []struct {
+		name       string
+		attrs      map[string]tftypes.Value
+		wantTags   string
+		wantLatest string
+	}{
+		{
+			name:       "all",
+			wantTags:   "14-v0.4.0-extensions-exts,15-v0.4.0-extensions-exts,16-v0.4.0-extensions-exts,16-v0.2.0,17-v0.2.0",
+			wantLatest: "17-v0.2.0",
+		},
+		{
+			name: "latest for pg 16",
+			attrs: map[string]tftypes.Value{
+				"pg_major_version": tftypes.NewValue(tftypes.Number, big.NewFloat(16)),
+			},
+			wantTags:   "16-v0.4.0-extensions-exts,16-v0.2.0",
+			wantLatest: "16-v0.2.0",
+		},
+		{
+			name: "deprecated pgvecto.rs",
+			attrs: map[string]tftypes.Value{
+				"pg_major_version":   tftypes.NewValue(tftypes.Number, big.NewFloat(16)),
+				"extension":          tftypes.NewValue(tftypes.String, "pgvecto.rs"),
+				"include_deprecated": tftypes.NewValue(tftypes.Bool, true),
+			},
+			wantTags:   "16-v0.3.0-extensions-exts,16-v0.4.0-extensions-exts",
+			wantLatest: "16-v0.4.0-extensions-exts",
+		},
+		{
+			name: "no match",
+			attrs: map[string]tftypes.Value{
+				"pg_major_version": tftypes.NewValue(tftypes.Number, big.NewFloat(17)),
+				"extension":        tftypes.NewValue(tftypes.String, "pgvecto.rs"),
+			},
+			wantLatest: "<null>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := p.readDataSource("pgvecto-rs-cloud_images", tt.attrs)
+			requireNoErrors(t, "read", diags)
+			if got := strings.Join(imageTags(t, state), ","); got != tt.wantTags {
+				t.Errorf("image tags = %q, want %q", got, tt.wantTags)
+			}
+			if got := stateAttr(t, state, "latest"); got != tt.wantLatest {
+				t.Errorf("latest = %q, want %q", got, tt.wantLatest)
+			}
+		})
+	}
+
+	_, diags := p.readDataSource("pgvecto-rs-cloud_images", map[string]tftypes.Value{
+		"extension": tftypes.NewValue(tftypes.String, "pgvector"),
+	})
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Invalid extension")
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpListImages, Status: 403, Code: 40300, Message: "permission denied"})
+	_, diags = p.readDataSource("pgvecto-rs-cloud_images", nil)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list images")
+}
+
+// imageTags returns the tags of the images listed in the state of the images
+// data source.
+func imageTags(t *testing.T, state tftypes.Value) []string {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatalf("state is not an object: %v", err)
+	}
+	var images []tftypes.Value
+	if err := attrs["images"].As(&images); err != nil {
+		t.Fatalf("images is not a list: %v", err)
+	}
+	var tags []string
+	for _, image := range images {
+		tags = append(tags, stateAttr(t, image, "tag"))
+	}
+	return tags
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PlansDataSource{}
+var _ datasource.DataSourceWithConfigure = &PlansDataSource{}
+
+func NewPlansDataSource() datasource.DataSource {
+	return &PlansDataSource{}
+}
+
+// PlansDataSource defines the data source listing the plans clusters can be
+// created with and their capabilities.
+type PlansDataSource struct {
+	client *client.Client
+}
+
+// PlansDataSourceModel describes the plans data model.
+type PlansDataSourceModel struct {
+	Plans []PlanModel `tfsdk:"plans"`
+}
+
+// PlanModel describes a plan of the plans data source.
+type PlanModel struct {
+	Name            types.String      `tfsdk:"name"`
+	Description     types.String      `tfsdk:"description"`
+	Type            types.String      `tfsdk:"type"`
+	ServerResources []types.String    `tfsdk:"server_resources"`
+	MinDiskSize     types.Int64       `tfsdk:"min_disk_size"`
+	MaxDiskSize     types.Int64       `tfsdk:"max_disk_size"`
+	Features        PlanFeaturesModel `tfsdk:"features"`
+}
+
+// PlanFeaturesModel describes the capabilities of a plan.
+type PlanFeaturesModel struct {
+	Pooler           types.Bool `tfsdk:"pooler"`
+	PITR             types.Bool `tfsdk:"pitr"`
+	HighAvailability types.Bool `tfsdk:"high_availability"`
+}
+
+func (d *PlansDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plans"
+}
+
+func (d *PlansDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Plans Data Source. Lists the plans clusters can be created with and their capabilities, so that configurations can depend on features rather than plan names.",
+		Attributes: map[string]schema.Attribute{
+			"plans": schema.ListNestedAttribute{
+				MarkdownDescription: "The plans.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the plan, to be used as the `plan` of a cluster.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the plan.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the clusters of the plan, Shared or Dedicated.",
+							Computed:            true,
+						},
+						"server_resources": schema.ListAttribute{
+							MarkdownDescription: "The server resources clusters of the plan can run on.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"min_disk_size": schema.Int64Attribute{
+							MarkdownDescription: "The minimum size of the PGData disk in GB.",
+							Computed:            true,
+						},
+						"max_disk_size": schema.Int64Attribute{
+							MarkdownDescription: "The maximum size of the PGData disk in GB.",
+							Computed:            true,
+						},
+						"features": schema.SingleNestedAttribute{
+							MarkdownDescription: "The capabilities of the clusters of the plan.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"pooler": schema.BoolAttribute{
+									MarkdownDescription: "Whether clusters can enable the connection pooler.",
+									Computed:            true,
+								},
+								"pitr": schema.BoolAttribute{
+									MarkdownDescription: "Whether clusters can be restored to a point in time.",
+									Computed:            true,
+								},
+								"high_availability": schema.BoolAttribute{
+									MarkdownDescription: "Whether clusters run with standby instances.",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *PlansDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *PlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state PlansDataSourceModel
+
+	tflog.Trace(ctx, "sending list plans request...")
+	plans, err := d.client.ListPlans(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list plans", err)
+		return
+	}
+
+	state.Plans = make([]PlanModel, 0, len(plans))
+	for _, plan := range plans {
+		model := PlanModel{
+			Name:            types.StringValue(string(plan.Plan)),
+			Description:     types.StringValue(plan.Description),
+			Type:            types.StringValue(string(plan.Type)),
+			ServerResources: make([]types.String, 0, len(plan.ServerResources)),
+			MinDiskSize:     types.Int64Value(int64(plan.MinDiskSize)),
+			MaxDiskSize:     types.Int64Value(int64(plan.MaxDiskSize)),
+			Features: PlanFeaturesModel{
+				Pooler:           types.BoolValue(plan.Features.Pooler),
+				PITR:             types.BoolValue(plan.Features.PITR),
+				HighAvailability: types.BoolValue(plan.Features.HighAvailability),
+			},
+		}
+		for _, resource := range plan.ServerResources {
+			model.ServerResources = append(model.ServerResources, types.StringValue(string(resource)))
+		}
+		state.Plans = append(state.Plans, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
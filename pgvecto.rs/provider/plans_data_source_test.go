@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+func TestPlansDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+
+	state, diags := p.readDataSource("pgvecto-rs-cloud_plans", nil)
+	requireNoErrors(t, "read", diags)
+
+	plans := listAttr(t, state, "plans")
+	if len(plans) != 2 {
+		t.Fatalf("plans = %d, want 2", len(plans))
+	}
+	tests := []struct {
+		plan tftypes.Value
+		want map[string]string
+	}{
+		{
+			plan: plans[0],
+			want: map[string]string{"name": "Starter", "type": "Shared", "max_disk_size": "64", "pooler": "false", "pitr": "false", "high_availability": "false"},
+		},
+		{
+			plan: plans[1],
+			want: map[string]string{"name": "Enterprise", "type": "Dedicated", "max_disk_size": "16384", "pooler": "true", "pitr": "true", "high_availability": "true"},
+		},
+	}
+	for _, tt := range tests {
+		var attrs map[string]tftypes.Value
+		if err := tt.plan.As(&attrs); err != nil {
+			t.Fatalf("plan is not an object: %v", err)
+		}
+		for name, want := range tt.want {
+			val := tt.plan
+			if name == "pooler" || name == "pitr" || name == "high_availability" {
+				val = attrs["features"]
+			}
+			if got := stateAttr(t, val, name); got != want {
+				t.Errorf("%s: %s = %q, want %q", tt.want["name"], name, got, want)
+			}
+		}
+	}
+	var resources []string
+	for _, r := range listAttr(t, plans[0], "server_resources") {
+		var s string
+		_ = r.As(&s)
+		resources = append(resources, s)
+	}
+	if len(resources) != 1 || resources[0] != "aws-t3-xlarge-4c-16g" {
+		t.Errorf("Starter server resources = %v, want [aws-t3-xlarge-4c-16g]", resources)
+	}
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpListPlans, Status: 403, Code: 40300, Message: "permission denied"})
+	_, diags = p.readDataSource("pgvecto-rs-cloud_plans", nil)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list plans")
+}
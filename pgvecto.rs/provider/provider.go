@@ -5,9 +5,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +21,7 @@ import (
 
 // Ensure PineconeProvider satisfies various provider interfaces.
 var _ provider.Provider = &PGVectorsProvider{}
+var _ provider.ProviderWithListResources = &PGVectorsProvider{}
 
 // PGVectorsProvider defines the provider implementation.
 type PGVectorsProvider struct {
@@ -24,12 +29,29 @@ type PGVectorsProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// httpClient replaces the HTTP client of the API client when set, e.g.
+	// to record and replay the acceptance tests.
+	httpClient client.HttpClient
+}
+
+// providerData is handed to data sources and resources when they are configured.
+type providerData struct {
+	client *client.Client
+	// defaultAccountID is used when an account is not given explicitly.
+	defaultAccountID string
+	// catalog caches the catalogs used to validate plans.
+	catalog *catalog
 }
 
 // PGVectorsProviderModel describes the provider data model.
 type PGVectorsProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
-	ApiURL types.String `tfsdk:"api_url"`
+	ApiKey       types.String `tfsdk:"api_key"`
+	ApiURL       types.String `tfsdk:"api_url"`
+	AccountId    types.String `tfsdk:"account_id"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
 }
 
 func (p *PGVectorsProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -53,6 +75,22 @@ by [PGVecto.rs Cloud](https://cloud.pgvecto.rs). You can refer to [get started g
 				MarkdownDescription: "The URL of the PGVecto.rs Cloud API.",
 				Optional:            true,
 			},
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "Default Account Identifier for the PGVecto.rs cloud, used to import clusters by their identifier alone. Can be configured by setting PGVECTORS_CLOUD_ACCOUNT_ID environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a failed API request is retried. Requests are retried on rate limiting (429), bad gateway or unavailable responses (502, 503, 504) and connection errors. Cluster creation requests carry an idempotency key, so retrying them never creates a duplicate cluster. Defaults to 4, set to 0 to disable retries.",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Minimum backoff between retries, such as \"1s\". The backoff doubles on each attempt up to `retry_wait_max`. A `Retry-After` header sent by the API takes precedence. Defaults to 1s.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum backoff between retries, such as \"30s\". Defaults to 30s.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -78,18 +116,75 @@ func (p *PGVectorsProvider) Configure(ctx context.Context, req provider.Configur
 		apiUrl = data.ApiURL.ValueString()
 	}
 
-	client, err := client.NewClient(
+	accountID := os.Getenv("PGVECTORS_CLOUD_ACCOUNT_ID")
+	if !data.AccountId.IsNull() {
+		accountID = data.AccountId.ValueString()
+	}
+
+	opts := []client.Option{
 		client.WithApiKey(apiKey),
 		client.OverrideApiUrl(apiUrl),
-	)
+	}
+
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries", "max_retries must not be negative.")
+			return
+		}
+		opts = append(opts, client.WithMaxRetries(int(data.MaxRetries.ValueInt64())))
+	}
+
+	if !data.RetryWaitMin.IsNull() || !data.RetryWaitMax.IsNull() {
+		waitMin, err := parseRetryWait(data.RetryWaitMin, client.DefaultRetryPolicy.WaitMin)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_wait_min"), "Invalid retry_wait_min", err.Error())
+			return
+		}
+		waitMax, err := parseRetryWait(data.RetryWaitMax, client.DefaultRetryPolicy.WaitMax)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_wait_max"), "Invalid retry_wait_max", err.Error())
+			return
+		}
+		if waitMin > waitMax {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_wait_min"), "Invalid retry_wait_min", "retry_wait_min must not be greater than retry_wait_max.")
+			return
+		}
+		opts = append(opts, client.WithRetryWait(waitMin, waitMax))
+	}
+
+	if p.httpClient != nil {
+		opts = append(opts, client.WithHTTPClient(p.httpClient))
+	}
+
+	client, err := client.NewClient(opts...)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create pgvecto.rs cloud client: %v", err.Error())
 		return
 	}
 
 	// PGVecto.rs Cloud client for data sources and resources
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	pd := &providerData{
+		client:           client,
+		defaultAccountID: accountID,
+		catalog:          newCatalog(client),
+	}
+	resp.DataSourceData = pd
+	resp.ResourceData = pd
+	resp.ListResourceData = pd
+}
+
+func parseRetryWait(value types.String, defaultWait time.Duration) (time.Duration, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultWait, nil
+	}
+	wait, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %q as a duration: %w", value.ValueString(), err)
+	}
+	if wait < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", value.ValueString())
+	}
+	return wait, nil
 }
 
 func (p *PGVectorsProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -98,9 +193,20 @@ func (p *PGVectorsProvider) Resources(ctx context.Context) []func() resource.Res
 	}
 }
 
+func (p *PGVectorsProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewClusterListResource,
+	}
+}
+
 func (p *PGVectorsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewClusterDataSource,
+		NewClustersDataSource,
+		NewImagesDataSource,
+		NewServerResourcesDataSource,
+		NewRegionsDataSource,
+		NewPlansDataSource,
 	}
 }
 
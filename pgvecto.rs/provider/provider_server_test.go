@@ -0,0 +1,522 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/cassette"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+// testAccountID is the account the hermetic tests manage clusters in.
+const testAccountID = "8364ded2-5580-45c4-a394-edfa582e35a0"
+
+// testProvider drives the provider through its tfprotov6 server against a
+// fake API, the way Terraform would, without the terraform binary or network.
+type testProvider struct {
+	t      *testing.T
+	api    *fake.Server
+	server tfprotov6.ProviderServer
+	schema *tfprotov6.GetProviderSchemaResponse
+	ids    *tfprotov6.GetResourceIdentitySchemasResponse
+}
+
+// testResource is a resource instance as Terraform keeps it in state.
+type testResource struct {
+	typeName string
+	state    tftypes.Value
+	identity *tfprotov6.ResourceIdentityData
+}
+
+func newTestProvider(t *testing.T, opts ...fake.Option) *testProvider {
+	t.Helper()
+
+	api := fake.NewServer(opts...)
+	t.Cleanup(api.Close)
+
+	p := startTestProvider(t, New("test")(), api.URL, "test")
+	p.api = api
+	return p
+}
+
+// newTestProviderWithRecorder returns a test provider whose API calls go
+// through rec instead of a fake API. p.api is nil.
+func newTestProviderWithRecorder(t *testing.T, rec *cassette.Recorder, apiURL, apiKey string) *testProvider {
+	t.Helper()
+	return startTestProvider(t, &PGVectorsProvider{version: "test", httpClient: rec}, apiURL, apiKey)
+}
+
+// startTestProvider serves prov over tfprotov6 and configures it for the API
+// at apiURL.
+func startTestProvider(t *testing.T, prov provider.Provider, apiURL, apiKey string) *testProvider {
+	t.Helper()
+	ctx := context.Background()
+
+	p := &testProvider{
+		t:      t,
+		server: providerserver.NewProtocol6(prov)(),
+	}
+
+	var err error
+	p.schema, err = p.server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() error = %v", err)
+	}
+	requireNoErrors(t, "GetProviderSchema", p.schema.Diagnostics)
+
+	p.ids, err = p.server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("GetResourceIdentitySchemas() error = %v", err)
+	}
+	requireNoErrors(t, "GetResourceIdentitySchemas", p.ids.Diagnostics)
+
+	config := p.object(p.schema.Provider, map[string]tftypes.Value{
+		"api_key":        tftypes.NewValue(tftypes.String, apiKey),
+		"api_url":        tftypes.NewValue(tftypes.String, apiURL),
+		"account_id":     tftypes.NewValue(tftypes.String, testAccountID),
+		"retry_wait_min": tftypes.NewValue(tftypes.String, "1ms"),
+		"retry_wait_max": tftypes.NewValue(tftypes.String, "5ms"),
+	})
+	resp, err := p.server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.12.0",
+		Config:           p.dynamicValue(config),
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider() error = %v", err)
+	}
+	requireNoErrors(t, "ConfigureProvider", resp.Diagnostics)
+
+	return p
+}
+
+// object returns a value of the schema type with the given attributes. The
+// attributes and blocks that are not given are null, as when they are left
+// out of the configuration.
+func (p *testProvider) object(schema *tfprotov6.Schema, attrs map[string]tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	typ := schema.ValueType().(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, val := range attrs {
+		if _, ok := typ.AttributeTypes[name]; !ok {
+			p.t.Fatalf("unknown attribute %q", name)
+		}
+		vals[name] = val
+	}
+	return tftypes.NewValue(typ, vals)
+}
+
+func (p *testProvider) dynamicValue(val tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(val.Type(), val)
+	if err != nil {
+		p.t.Fatalf("NewDynamicValue() error = %v", err)
+	}
+	return &dv
+}
+
+func (p *testProvider) value(typ tftypes.Type, dv *tfprotov6.DynamicValue) tftypes.Value {
+	p.t.Helper()
+	if dv == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	val, err := dv.Unmarshal(typ)
+	if err != nil {
+		p.t.Fatalf("DynamicValue.Unmarshal() error = %v", err)
+	}
+	return val
+}
+
+func (p *testProvider) resourceSchema(typeName string) *tfprotov6.Schema {
+	p.t.Helper()
+	schema, ok := p.schema.ResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("unknown resource type %q", typeName)
+	}
+	return schema
+}
+
+// config returns a resource configuration from its attributes.
+func (p *testProvider) config(typeName string, attrs map[string]tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	return p.object(p.resourceSchema(typeName), attrs)
+}
+
+// plan validates and plans config like terraform plan. prior is nil when the
+// resource is created. When the configuration is invalid, the response only
+// holds the diagnostics of the validation.
+func (p *testProvider) plan(typeName string, prior *testResource, config tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	p.t.Helper()
+	ctx := context.Background()
+	schema := p.resourceSchema(typeName)
+
+	validateResp, err := p.server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(config),
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateResourceConfig() error = %v", err)
+	}
+	if hasErrors(validateResp.Diagnostics) {
+		return &tfprotov6.PlanResourceChangeResponse{Diagnostics: validateResp.Diagnostics}
+	}
+
+	priorState := tftypes.NewValue(schema.ValueType(), nil)
+	var priorIdentity *tfprotov6.ResourceIdentityData
+	if prior != nil {
+		priorState = prior.state
+		priorIdentity = prior.identity
+	}
+
+	planResp, err := p.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       p.dynamicValue(priorState),
+		ProposedNewState: p.dynamicValue(proposedNewState(schema, priorState, config)),
+		Config:           p.dynamicValue(config),
+		PriorIdentity:    priorIdentity,
+	})
+	if err != nil {
+		p.t.Fatalf("PlanResourceChange() error = %v", err)
+	}
+	return planResp
+}
+
+// apply plans and applies config like terraform apply. prior is nil when the
+// resource is created. The diagnostics of the plan and the apply are returned
+// together; the resource is nil when either failed.
+func (p *testProvider) apply(typeName string, prior *testResource, config tftypes.Value) (*testResource, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	ctx := context.Background()
+	typ := p.resourceSchema(typeName).ValueType()
+	priorState := tftypes.NewValue(typ, nil)
+	if prior != nil {
+		priorState = prior.state
+	}
+
+	planResp := p.plan(typeName, prior, config)
+	diags := planResp.Diagnostics
+	if hasErrors(diags) {
+		return nil, diags
+	}
+	if prior != nil && len(planResp.RequiresReplace) > 0 {
+		p.t.Fatalf("PlanResourceChange() requires replacing the resource: %v", planResp.RequiresReplace)
+	}
+
+	applyResp, err := p.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        typeName,
+		PriorState:      p.dynamicValue(priorState),
+		PlannedState:    planResp.PlannedState,
+		Config:          p.dynamicValue(config),
+		PlannedPrivate:  planResp.PlannedPrivate,
+		PlannedIdentity: planResp.PlannedIdentity,
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange() error = %v", err)
+	}
+	diags = append(diags, applyResp.Diagnostics...)
+	if hasErrors(diags) {
+		return nil, diags
+	}
+
+	planned := p.value(typ, planResp.PlannedState)
+	applied := p.value(typ, applyResp.NewState)
+	checkConsistentResult(p.t, planned, applied)
+
+	return &testResource{typeName: typeName, state: applied, identity: applyResp.NewIdentity}, diags
+}
+
+// destroy plans and applies the deletion of r like terraform destroy.
+func (p *testProvider) destroy(r *testResource) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+	ctx := context.Background()
+	typ := p.resourceSchema(r.typeName).ValueType()
+	null := p.dynamicValue(tftypes.NewValue(typ, nil))
+
+	planResp, err := p.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       p.dynamicValue(r.state),
+		ProposedNewState: null,
+		Config:           null,
+		PriorIdentity:    r.identity,
+	})
+	if err != nil {
+		p.t.Fatalf("PlanResourceChange() error = %v", err)
+	}
+	if hasErrors(planResp.Diagnostics) {
+		return planResp.Diagnostics
+	}
+
+	applyResp, err := p.server.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     r.typeName,
+		PriorState:   p.dynamicValue(r.state),
+		PlannedState: null,
+		Config:       null,
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange() error = %v", err)
+	}
+	return append(planResp.Diagnostics, applyResp.Diagnostics...)
+}
+
+// read refreshes r like terraform refresh. The returned resource is nil when
+// the provider removed it from state.
+func (p *testProvider) read(r *testResource) (*testResource, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	typ := p.resourceSchema(r.typeName).ValueType()
+
+	resp, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:        r.typeName,
+		CurrentState:    p.dynamicValue(r.state),
+		CurrentIdentity: r.identity,
+	})
+	if err != nil {
+		p.t.Fatalf("ReadResource() error = %v", err)
+	}
+	if hasErrors(resp.Diagnostics) {
+		return nil, resp.Diagnostics
+	}
+
+	state := p.value(typ, resp.NewState)
+	if state.IsNull() {
+		return nil, resp.Diagnostics
+	}
+	return &testResource{typeName: r.typeName, state: state, identity: resp.NewIdentity}, resp.Diagnostics
+}
+
+// importState imports a resource like terraform import, by id or by identity,
+// and reads it.
+func (p *testProvider) importState(typeName string, id string, identity map[string]tftypes.Value) (*testResource, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	typ := p.resourceSchema(typeName).ValueType()
+
+	req := &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	}
+	if identity != nil {
+		identityType := p.ids.IdentitySchemas[typeName].ValueType()
+		req.Identity = &tfprotov6.ResourceIdentityData{
+			IdentityData: p.dynamicValue(tftypes.NewValue(identityType, identity)),
+		}
+	}
+
+	resp, err := p.server.ImportResourceState(context.Background(), req)
+	if err != nil {
+		p.t.Fatalf("ImportResourceState() error = %v", err)
+	}
+	if hasErrors(resp.Diagnostics) {
+		return nil, resp.Diagnostics
+	}
+	if len(resp.ImportedResources) != 1 {
+		p.t.Fatalf("ImportResourceState() imported %d resources, want 1", len(resp.ImportedResources))
+	}
+
+	imported := resp.ImportedResources[0]
+	r := &testResource{
+		typeName: typeName,
+		state:    p.value(typ, imported.State),
+		identity: imported.Identity,
+	}
+	return p.read(r)
+}
+
+// readDataSource reads a data source like terraform plan would.
+func (p *testProvider) readDataSource(typeName string, attrs map[string]tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	ctx := context.Background()
+	schema, ok := p.schema.DataSourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("unknown data source type %q", typeName)
+	}
+	typ := schema.ValueType()
+	config := p.dynamicValue(p.object(schema, attrs))
+
+	validateResp, err := p.server.ValidateDataResourceConfig(ctx, &tfprotov6.ValidateDataResourceConfigRequest{
+		TypeName: typeName,
+		Config:   config,
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateDataResourceConfig() error = %v", err)
+	}
+	if hasErrors(validateResp.Diagnostics) {
+		return tftypes.NewValue(typ, nil), validateResp.Diagnostics
+	}
+
+	resp, err := p.server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   config,
+	})
+	if err != nil {
+		p.t.Fatalf("ReadDataSource() error = %v", err)
+	}
+	return p.value(typ, resp.State), resp.Diagnostics
+}
+
+// list runs a list block like terraform query would and collects its
+// results.
+func (p *testProvider) list(typeName string, attrs map[string]tftypes.Value, includeResource bool, limit int64) ([]tfprotov6.ListResourceResult, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	ctx := context.Background()
+	schema, ok := p.schema.ListResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("unknown list resource type %q", typeName)
+	}
+	server, ok := p.server.(tfprotov6.ListResourceServer)
+	if !ok {
+		p.t.Fatalf("provider server does not support list resources")
+	}
+	config := p.dynamicValue(p.object(schema, attrs))
+
+	validateResp, err := server.ValidateListResourceConfig(ctx, &tfprotov6.ValidateListResourceConfigRequest{
+		TypeName: typeName,
+		Config:   config,
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateListResourceConfig() error = %v", err)
+	}
+	if hasErrors(validateResp.Diagnostics) {
+		return nil, validateResp.Diagnostics
+	}
+
+	stream, err := server.ListResource(ctx, &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          config,
+		IncludeResource: includeResource,
+		Limit:           limit,
+	})
+	if err != nil {
+		p.t.Fatalf("ListResource() error = %v", err)
+	}
+	var results []tfprotov6.ListResourceResult
+	var diags []*tfprotov6.Diagnostic
+	for result := range stream.Results {
+		diags = append(diags, result.Diagnostics...)
+		if !hasErrors(result.Diagnostics) {
+			results = append(results, result)
+		}
+	}
+	return results, diags
+}
+
+// proposedNewState merges the configuration into the prior state the way
+// Terraform does before planning: computed attributes that are not
+// configured keep their prior value.
+func proposedNewState(schema *tfprotov6.Schema, prior, config tftypes.Value) tftypes.Value {
+	if prior.IsNull() {
+		return config
+	}
+
+	var priorAttrs, configAttrs map[string]tftypes.Value
+	_ = prior.As(&priorAttrs)
+	_ = config.As(&configAttrs)
+
+	proposed := make(map[string]tftypes.Value, len(configAttrs))
+	for name, val := range configAttrs {
+		proposed[name] = val
+	}
+	for _, attr := range schema.Block.Attributes {
+		if attr.Computed && configAttrs[attr.Name].IsNull() {
+			proposed[attr.Name] = priorAttrs[attr.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), proposed)
+}
+
+// checkConsistentResult fails the test when the provider applied a value that
+// differs from a known planned value, which Terraform reports as "Provider
+// produced inconsistent result after apply".
+func checkConsistentResult(t *testing.T, planned, applied tftypes.Value) {
+	t.Helper()
+	var plannedAttrs, appliedAttrs map[string]tftypes.Value
+	_ = planned.As(&plannedAttrs)
+	_ = applied.As(&appliedAttrs)
+
+	for name, want := range plannedAttrs {
+		if !want.IsFullyKnown() {
+			continue
+		}
+		if got := appliedAttrs[name]; !got.Equal(want) {
+			t.Errorf("inconsistent result after apply: %s planned %s, got %s", name, want, got)
+		}
+	}
+}
+
+// stateAttr returns a primitive attribute of a state or data source value as a
+// string, "<null>" when it is null.
+func stateAttr(t *testing.T, val tftypes.Value, name string) string {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		t.Fatalf("value is not an object: %v", err)
+	}
+	v, ok := attrs[name]
+	if !ok {
+		t.Fatalf("unknown attribute %q", name)
+	}
+	if v.IsNull() {
+		return "<null>"
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return s
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		_ = v.As(&b)
+		return fmt.Sprint(b)
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		return n.Text('g', -1)
+	default:
+		return v.String()
+	}
+}
+
+func hasErrors(diags []*tfprotov6.Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func requireNoErrors(t *testing.T, op string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	if hasErrors(diags) {
+		t.Fatalf("%s returned errors:\n%s", op, formatDiagnostics(diags))
+	}
+}
+
+// requireDiagnostic fails the test unless diags has a diagnostic of the given
+// severity whose summary contains summary.
+func requireDiagnostic(t *testing.T, diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, summary string) *tfprotov6.Diagnostic {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == severity && strings.Contains(d.Summary, summary) {
+			return d
+		}
+	}
+	t.Fatalf("no %s diagnostic %q in:\n%s", severity, summary, formatDiagnostics(diags))
+	return nil
+}
+
+func formatDiagnostics(diags []*tfprotov6.Diagnostic) string {
+	var b strings.Builder
+	for _, d := range diags {
+		fmt.Fprintf(&b, "%s: %s: %s\n", d.Severity, d.Summary, d.Detail)
+	}
+	return b.String()
+}
@@ -3,11 +3,13 @@ package provider
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/cassette"
 )
 
 var (
@@ -20,6 +22,82 @@ var (
 	}
 )
 
+// testAccProtoV6ProviderFactoriesWithRecorder returns provider factories
+// whose API calls go through rec. rec may be nil.
+func testAccProtoV6ProviderFactoriesWithRecorder(rec *cassette.Recorder) map[string]func() (tfprotov6.ProviderServer, error) {
+	if rec == nil {
+		return testAccProtoV6ProviderFactories
+	}
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"pgvecto-rs-cloud": providerserver.NewProtocol6WithError(&PGVectorsProvider{
+			version:    "test",
+			httpClient: rec,
+		}),
+	}
+}
+
+// newTestAccRecorder returns the cassette recorder of an acceptance test, or
+// nil when PGVECTORS_CLOUD_CASSETTE is not set. With "record" the test runs
+// against the API and its interactions are saved to
+// testdata/cassettes/<test name>.json, with "replay" it runs from that file
+// without an API key or network access.
+func newTestAccRecorder(t *testing.T) *cassette.Recorder {
+	t.Helper()
+	mode := cassette.Mode(os.Getenv("PGVECTORS_CLOUD_CASSETTE"))
+	if mode == "" {
+		return nil
+	}
+
+	if mode == cassette.ModeReplay {
+		if _, err := os.Stat(testCassettePath(t)); os.IsNotExist(err) {
+			t.Skipf("no cassette recorded at %s", testCassettePath(t))
+		}
+	}
+
+	rec := newTestRecorder(t, mode)
+	apiURL := rec.Value("api_url", func() string { return os.Getenv("PGVECTORS_CLOUD_API_URL") })
+	if mode == cassette.ModeReplay {
+		t.Setenv("PGVECTORS_CLOUD_API_KEY", "replay")
+		t.Setenv("PGVECTORS_CLOUD_API_URL", apiURL)
+	}
+	return rec
+}
+
+// testCassettePath returns the cassette file of the running test.
+func testCassettePath(t *testing.T) string {
+	return filepath.Join("testdata", "cassettes", t.Name()+".json")
+}
+
+// newTestRecorder returns a recorder for the cassette of the running test.
+// The cassette is written, or checked to be fully replayed, when the test
+// ends.
+func newTestRecorder(t *testing.T, mode cassette.Mode) *cassette.Recorder {
+	t.Helper()
+	rec, err := cassette.New(testCassettePath(t), mode, nil)
+	if err != nil {
+		t.Fatalf("cassette.New() error = %v", err)
+	}
+	t.Cleanup(func() {
+		// Keep the previous cassette when the test did not run or failed.
+		if t.Skipped() || (mode == cassette.ModeRecord && t.Failed()) {
+			return
+		}
+		if err := rec.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return rec
+}
+
+// testAccValue returns a test input that is recorded in the cassette, so
+// that replays send the same requests as the recording.
+func testAccValue(rec *cassette.Recorder, key string, fn func() string) string {
+	if rec == nil {
+		return fn()
+	}
+	return rec.Value(key, fn)
+}
+
 // Test the provider configuration with variables set.
 func TestProvider(t *testing.T) {
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RegionsDataSource{}
+var _ datasource.DataSourceWithConfigure = &RegionsDataSource{}
+
+func NewRegionsDataSource() datasource.DataSource {
+	return &RegionsDataSource{}
+}
+
+// RegionsDataSource defines the data source listing the regions clusters
+// can be created in.
+type RegionsDataSource struct {
+	client *client.Client
+}
+
+// RegionsDataSourceModel describes the regions data model.
+type RegionsDataSourceModel struct {
+	Plan    types.String  `tfsdk:"plan"`
+	Regions []RegionModel `tfsdk:"regions"`
+}
+
+// RegionModel describes a region of the regions data source.
+type RegionModel struct {
+	Name            types.String   `tfsdk:"name"`
+	ClusterProvider types.String   `tfsdk:"cluster_provider"`
+	Description     types.String   `tfsdk:"description"`
+	Plans           []types.String `tfsdk:"plans"`
+}
+
+func (d *RegionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_regions"
+}
+
+func (d *RegionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Regions Data Source. Lists the regions clusters can be created in, optionally filtered by plan.",
+		Attributes: map[string]schema.Attribute{
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "Only list regions available with this plan, e.g. Starter.",
+				Optional:            true,
+			},
+			"regions": schema.ListNestedAttribute{
+				MarkdownDescription: "The regions matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the region, to be used as the `region` of a cluster.",
+							Computed:            true,
+						},
+						"cluster_provider": schema.StringAttribute{
+							MarkdownDescription: "The cloud provider of the region.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The location of the region, e.g. US East (N. Virginia).",
+							Computed:            true,
+						},
+						"plans": schema.ListAttribute{
+							MarkdownDescription: "The plans available in the region.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RegionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *RegionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state RegionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "sending list regions request...")
+	regions, err := d.client.ListRegions(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list regions", err)
+		return
+	}
+
+	state.Regions = make([]RegionModel, 0, len(regions))
+	for _, region := range regions {
+		if !state.Plan.IsNull() && !region.AvailableWith(client.CNPGClusterPlan(state.Plan.ValueString())) {
+			continue
+		}
+		model := RegionModel{
+			Name:            types.StringValue(region.Name),
+			ClusterProvider: types.StringValue(string(region.ClusterProvider)),
+			Description:     types.StringValue(region.Description),
+			Plans:           make([]types.String, 0, len(region.Plans)),
+		}
+		for _, plan := range region.Plans {
+			model.Plans = append(model.Plans, types.StringValue(string(plan)))
+		}
+		state.Regions = append(state.Regions, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
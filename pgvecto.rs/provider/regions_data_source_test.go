@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+func TestRegionsDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+	p.api.SetRegions([]client.RegionInfo{
+		{Name: "us-east-1", ClusterProvider: client.AWSCloudProvider, Description: "US East (N. Virginia)", Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanStarter, client.CNPGClusterPlanEnterprise}},
+		{Name: "eu-west-1", ClusterProvider: client.AWSCloudProvider, Description: "Europe (Ireland)", Plans: []client.CNPGClusterPlan{client.CNPGClusterPlanEnterprise}},
+	})
+
+	tests := []struct {
+		name      string
+		attrs     map[string]tftypes.Value
+		wantNames string
+	}{
+		{name: "all", wantNames: "us-east-1,eu-west-1"},
+		{
+			name:      "starter",
+			attrs:     map[string]tftypes.Value{"plan": tftypes.NewValue(tftypes.String, "Starter")},
+			wantNames: "us-east-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := p.readDataSource("pgvecto-rs-cloud_regions", tt.attrs)
+			requireNoErrors(t, "read", diags)
+			var names []string
+			for _, region := range listAttr(t, state, "regions") {
+				names = append(names, stateAttr(t, region, "name"))
+			}
+			if got := strings.Join(names, ","); got != tt.wantNames {
+				t.Errorf("regions = %q, want %q", got, tt.wantNames)
+			}
+		})
+	}
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpListRegions, Status: 403, Code: 40300, Message: "permission denied"})
+	_, diags := p.readDataSource("pgvecto-rs-cloud_regions", nil)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list regions")
+}
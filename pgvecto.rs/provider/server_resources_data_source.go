@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ServerResourcesDataSource{}
+var _ datasource.DataSourceWithConfigure = &ServerResourcesDataSource{}
+
+func NewServerResourcesDataSource() datasource.DataSource {
+	return &ServerResourcesDataSource{}
+}
+
+// ServerResourcesDataSource defines the data source listing the instance
+// types clusters can run on.
+type ServerResourcesDataSource struct {
+	client *client.Client
+}
+
+// ServerResourcesDataSourceModel describes the server resources data model.
+type ServerResourcesDataSourceModel struct {
+	Plan            types.String          `tfsdk:"plan"`
+	Region          types.String          `tfsdk:"region"`
+	ServerResources []ServerResourceModel `tfsdk:"server_resources"`
+}
+
+// ServerResourceModel describes an instance type of the server resources
+// data source.
+type ServerResourceModel struct {
+	Name            types.String   `tfsdk:"name"`
+	ClusterProvider types.String   `tfsdk:"cluster_provider"`
+	VCPU            types.Int64    `tfsdk:"vcpu"`
+	MemoryGiB       types.Int64    `tfsdk:"memory_gib"`
+	PricePerHour    types.Float64  `tfsdk:"price_per_hour"`
+	Plans           []types.String `tfsdk:"plans"`
+	Regions         []types.String `tfsdk:"regions"`
+}
+
+func (d *ServerResourcesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_resources"
+}
+
+func (d *ServerResourcesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Server Resources Data Source. Lists the instance types clusters can run on, with their size and price, optionally filtered by plan and region.",
+		Attributes: map[string]schema.Attribute{
+			"plan": schema.StringAttribute{
+				MarkdownDescription: "Only list instance types available with this plan, e.g. Enterprise.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Only list instance types available in this region, e.g. us-east-1.",
+				Optional:            true,
+			},
+			"server_resources": schema.ListNestedAttribute{
+				MarkdownDescription: "The instance types matching the filters.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the instance type, to be used as the `server_resource` of a cluster.",
+							Computed:            true,
+						},
+						"cluster_provider": schema.StringAttribute{
+							MarkdownDescription: "The cloud provider of the instance type.",
+							Computed:            true,
+						},
+						"vcpu": schema.Int64Attribute{
+							MarkdownDescription: "The number of virtual CPUs.",
+							Computed:            true,
+						},
+						"memory_gib": schema.Int64Attribute{
+							MarkdownDescription: "The memory in GiB.",
+							Computed:            true,
+						},
+						"price_per_hour": schema.Float64Attribute{
+							MarkdownDescription: "The price of a cluster instance in USD per hour.",
+							Computed:            true,
+						},
+						"plans": schema.ListAttribute{
+							MarkdownDescription: "The plans the instance type is available with.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"regions": schema.ListAttribute{
+							MarkdownDescription: "The regions the instance type is available in.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ServerResourcesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = data.client
+}
+
+func (d *ServerResourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ServerResourcesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "sending list server resources request...")
+	resources, err := d.client.ListServerResources(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Unable to list server resources", err)
+		return
+	}
+
+	state.ServerResources = make([]ServerResourceModel, 0, len(resources))
+	for _, r := range resources {
+		if (!state.Plan.IsNull() && !r.AvailableWith(client.CNPGClusterPlan(state.Plan.ValueString()))) ||
+			(!state.Region.IsNull() && !r.AvailableIn(state.Region.ValueString())) {
+			continue
+		}
+		model := ServerResourceModel{
+			Name:            types.StringValue(string(r.Name)),
+			ClusterProvider: types.StringValue(string(r.ClusterProvider)),
+			VCPU:            types.Int64Value(int64(r.VCPU)),
+			MemoryGiB:       types.Int64Value(int64(r.MemoryGiB)),
+			PricePerHour:    types.Float64Value(r.PricePerHour),
+			Plans:           make([]types.String, 0, len(r.Plans)),
+			Regions:         make([]types.String, 0, len(r.Regions)),
+		}
+		for _, plan := range r.Plans {
+			model.Plans = append(model.Plans, types.StringValue(string(plan)))
+		}
+		for _, region := range r.Regions {
+			model.Regions = append(model.Regions, types.StringValue(region))
+		}
+		state.ServerResources = append(state.ServerResources, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+func TestServerResourcesDataSource_Read(t *testing.T) {
+	p := newTestProvider(t)
+
+	tests := []struct {
+		name      string
+		attrs     map[string]tftypes.Value
+		wantNames string
+	}{
+		{
+			name:      "all",
+			wantNames: "aws-t3-xlarge-4c-16g,aws-m7i-large-2c-8g,aws-r7i-large-2c-16g,aws-r7i-xlarge-4c-32g,aws-i4i-xlarge-4c-32g",
+		},
+		{
+			name:      "starter",
+			attrs:     map[string]tftypes.Value{"plan": tftypes.NewValue(tftypes.String, "Starter")},
+			wantNames: "aws-t3-xlarge-4c-16g",
+		},
+		{
+			name: "enterprise in eu-west-1",
+			attrs: map[string]tftypes.Value{
+				"plan":   tftypes.NewValue(tftypes.String, "Enterprise"),
+				"region": tftypes.NewValue(tftypes.String, "eu-west-1"),
+			},
+			wantNames: "aws-m7i-large-2c-8g,aws-r7i-large-2c-16g,aws-r7i-xlarge-4c-32g",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, diags := p.readDataSource("pgvecto-rs-cloud_server_resources", tt.attrs)
+			requireNoErrors(t, "read", diags)
+			items := listAttr(t, state, "server_resources")
+			var names []string
+			for _, item := range items {
+				names = append(names, stateAttr(t, item, "name"))
+			}
+			if got := strings.Join(names, ","); got != tt.wantNames {
+				t.Errorf("server resources = %q, want %q", got, tt.wantNames)
+			}
+		})
+	}
+
+	state, diags := p.readDataSource("pgvecto-rs-cloud_server_resources", map[string]tftypes.Value{
+		"plan": tftypes.NewValue(tftypes.String, "Starter"),
+	})
+	requireNoErrors(t, "read", diags)
+	starter := listAttr(t, state, "server_resources")[0]
+	for name, want := range map[string]string{
+		"vcpu":           "4",
+		"memory_gib":     "16",
+		"price_per_hour": "0.1664",
+	} {
+		if got := stateAttr(t, starter, name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	p.api.InjectFault(fake.Fault{Op: fake.OpListServerResources, Status: 403, Code: 40300, Message: "permission denied"})
+	_, diags = p.readDataSource("pgvecto-rs-cloud_server_resources", nil)
+	requireDiagnostic(t, diags, tfprotov6.DiagnosticSeverityError, "Unable to list server resources")
+}
+
+// listAttr returns the elements of the list attribute name of an object.
+func listAttr(t *testing.T, val tftypes.Value, name string) []tftypes.Value {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := val.As(&attrs); err != nil {
+		t.Fatalf("value is not an object: %v", err)
+	}
+	var items []tftypes.Value
+	if err := attrs[name].As(&items); err != nil {
+		t.Fatalf("%s is not a list: %v", name, err)
+	}
+	return items
+}
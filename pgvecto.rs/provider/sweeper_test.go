@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client"
+	"github.com/tensorchord/terraform-provider-pgvecto-rs-cloud/client/fake"
+)
+
+// testAccClusterPrefix prefixes the names of the clusters created by the
+// acceptance tests, so that leaked ones can be swept.
+const testAccClusterPrefix = "tftest"
+
+// defaultSweepMinAge keeps the clusters of acceptance tests that may still be
+// running from being swept.
+const defaultSweepMinAge = 2 * time.Hour
+
+// TestMain runs the sweepers when the tests are run with -sweep, e.g.
+//
+//	go test ./pgvecto.rs/provider -v -sweep=all
+//
+// The region given to -sweep limits the clusters swept, "all" sweeps every
+// region. The accounts are read from PGVECTORS_CLOUD_SWEEP_ACCOUNT_IDS,
+// separated by commas, rather than from PGVECTORS_CLOUD_ACCOUNT_ID, which is
+// the single default account of the provider. PGVECTORS_CLOUD_SWEEP_MIN_AGE
+// overrides the minimum age of the swept clusters.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("pgvecto-rs-cloud_cluster", &resource.Sweeper{
+		Name: "pgvecto-rs-cloud_cluster",
+		F:    sweepClusters,
+	})
+}
+
+func sweepClusters(region string) error {
+	c, err := client.NewClient(
+		client.WithApiKey(os.Getenv("PGVECTORS_CLOUD_API_KEY")),
+		client.OverrideApiUrl(os.Getenv("PGVECTORS_CLOUD_API_URL")),
+	)
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+
+	var accountIDs []string
+	for _, id := range strings.Split(os.Getenv("PGVECTORS_CLOUD_SWEEP_ACCOUNT_IDS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			accountIDs = append(accountIDs, id)
+		}
+	}
+	if len(accountIDs) == 0 {
+		return errors.New("PGVECTORS_CLOUD_SWEEP_ACCOUNT_IDS must list the accounts to sweep")
+	}
+
+	minAge := defaultSweepMinAge
+	if v := os.Getenv("PGVECTORS_CLOUD_SWEEP_MIN_AGE"); v != "" {
+		if minAge, err = time.ParseDuration(v); err != nil {
+			return fmt.Errorf("parse PGVECTORS_CLOUD_SWEEP_MIN_AGE: %w", err)
+		}
+	}
+
+	if region == "all" {
+		region = ""
+	}
+	return sweepAccountClusters(context.Background(), c, accountIDs, region, minAge, time.Now())
+}
+
+// sweepAccountClusters deletes the clusters of the acceptance tests that were
+// last updated more than minAge before now. Clusters are only swept when
+// their name starts with testAccClusterPrefix followed by a dash.
+func sweepAccountClusters(ctx context.Context, c *client.Client, accountIDs []string, region string, minAge time.Duration, now time.Time) error {
+	var errs []error
+	for _, accountID := range accountIDs {
+		clusters, err := c.ListAllClusters(ctx, accountID, client.ListClustersOptions{
+			NamePrefix: testAccClusterPrefix + "-",
+			Region:     region,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("list clusters of account %s: %w", accountID, err))
+			continue
+		}
+
+		for _, cluster := range clusters {
+			if !strings.HasPrefix(cluster.Spec.Name, testAccClusterPrefix+"-") ||
+				cluster.Status.Status == client.CNPGClusterStatusDeleted ||
+				now.Sub(cluster.Status.UpdatedAt) < minAge {
+				continue
+			}
+
+			log.Printf("[INFO] Deleting cluster %s (%s) of account %s", cluster.Spec.Name, cluster.Spec.ID, accountID)
+			err := c.DeleteCluster(ctx, accountID, cluster.Spec.ID)
+			if err != nil && !errors.Is(err, client.ErrNotFound) {
+				errs = append(errs, fmt.Errorf("delete cluster %s of account %s: %w", cluster.Spec.ID, accountID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func TestSweepAccountClusters(t *testing.T) {
+	api := fake.NewServer()
+	defer api.Close()
+	c, err := client.NewClient(client.WithApiKey("test"), client.OverrideApiUrl(api.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	now := time.Now()
+	old := now.Add(-3 * time.Hour)
+	add := func(accountID, name, region string, updatedAt time.Time) string {
+		return api.AddCluster(accountID, client.CNPGCluster{
+			Spec:   client.CNPGClusterSpec{Name: name, ClusterProvider: client.ClusterProvider{Region: region}},
+			Status: client.CNPGClusterStatus{UpdatedAt: updatedAt},
+		}).Spec.ID
+	}
+
+	tests := []struct {
+		name      string
+		accountID string
+		id        string
+		wantSwept bool
+	}{
+		{name: "leaked", accountID: testAccountID, id: add(testAccountID, "tftest-1234", "us-east-1", old), wantSwept: true},
+		{name: "other account", accountID: "other", id: add("other", "tftest-5678", "us-east-1", old), wantSwept: true},
+		{name: "running", accountID: testAccountID, id: add(testAccountID, "tftest-9012", "us-east-1", now)},
+		{name: "other region", accountID: testAccountID, id: add(testAccountID, "tftest-3456", "eu-west-1", old)},
+		{name: "not a test cluster", accountID: testAccountID, id: add(testAccountID, "tftesting", "us-east-1", old)},
+	}
+
+	err = sweepAccountClusters(context.Background(), c, []string{testAccountID, "other"}, "us-east-1", defaultSweepMinAge, now)
+	if err != nil {
+		t.Fatalf("sweepAccountClusters() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		_, err := c.GetCluster(context.Background(), tt.accountID, tt.id)
+		if swept := errors.Is(err, client.ErrNotFound); swept != tt.wantSwept {
+			t.Errorf("%s: swept = %v, want %v", tt.name, swept, tt.wantSwept)
+		}
+	}
+}